@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/adamhassel/semvergo/pkg/flags"
+	git2 "github.com/adamhassel/semvergo/pkg/git"
+	"github.com/adamhassel/semvergo/pkg/scheme"
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+var branchCounter flags.Bool
+
+func init() {
+	flag.Var(&branchCounter, "branch-counter", "compute a branch build version, like 1.4.0-feature-login.1. The version is incremented from the latest release tag, or -v, and the counter is one more than that of any tag of the same version and branch. Characters in the branch name other than alphanumerics and '-' are replaced by '-'")
+}
+
+// checkBranchCounter returns an error if -branch-counter is combined with flags setting the pre-release
+func checkBranchCounter() error {
+	if !branchCounter.Bool() {
+		return nil
+	}
+	for _, f := range []string{"suffix", "branch", "inc", "pseudo", "nightly"} {
+		if isSet(f) {
+			return fmt.Errorf("-branch-counter can't be combined with -%s", f)
+		}
+	}
+	return nil
+}
+
+// branchCounterVersion returns sv with the current branch and a counter of its tags as the pre-release
+func branchCounterVersion(s scheme.Scheme, sv semver.SemVer) (semver.SemVer, error) {
+	repo, err := openRepo()
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	opts, err := tagOptions(s)
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	return git2.BranchCounter(repo, opts, sv)
+}
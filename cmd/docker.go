@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/adamhassel/semvergo/pkg/docker"
+	"github.com/adamhassel/semvergo/pkg/flags"
+	git2 "github.com/adamhassel/semvergo/pkg/git"
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+var dockerPrefixes flags.Strings
+var dockerNoLatest flags.Bool
+
+func init() {
+	commands["docker-tags"] = command{
+		flags: func() {
+			flag.Var(&dockerPrefixes, "tag-prefix", "prefix to add to every image tag. May be given multiple times, each prefix yields a full set of tags")
+			flag.Var(&dockerNoLatest, "no-latest", "never emit the 'latest' tag")
+		},
+		run: dockerTags,
+	}
+}
+
+// dockerTags prints the image tags to publish for the version, one per line. Floating tags are only included if the version is the highest in its line among the repository's version tags, which are parsed and compared in the version scheme
+func dockerTags(c computed) error {
+	sv, err := c.semver()
+	if err != nil {
+		return err
+	}
+	var existing []semver.SemVer
+	opts, err := tagOptions(c.scheme)
+	if err != nil {
+		return err
+	}
+	repo, err := openRepo()
+	if err != nil {
+		return err
+	}
+	for _, v := range git2.VersionTags(repo, opts) {
+		existing = append(existing, v.(semver.SemVer))
+	}
+	tags := docker.Tags(sv, existing, docker.Options{Prefixes: dockerPrefixes.Strings(), NoLatest: dockerNoLatest.Bool()})
+	for _, t := range tags {
+		if _, err := fmt.Println(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/adamhassel/semvergo/pkg/flags"
+)
+
+var explain flags.Bool
+var explainFormat flags.String
+
+// trace logs the steps of the version computation. It discards everything unless -explain is given
+var trace = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// traceOutput is where -explain writes the trace
+var traceOutput io.Writer = os.Stderr
+
+func init() {
+	flag.Var(&explain, "explain", "print a trace of how the version is computed to stderr: the tags scanned and why any were rejected, the base version, the increment and its source, and any overrides")
+	flag.Var(&explainFormat, "explain-format", "format of the -explain trace, 'text' or 'json'. Default is 'text'")
+}
+
+// setupTrace sets trace to log to stderr in the -explain-format, if -explain is given
+func setupTrace() error {
+	if !explain.Bool() {
+		if explainFormat.IsSet() {
+			return fmt.Errorf("-explain-format requires -explain")
+		}
+		return nil
+	}
+	switch explainFormat.String() {
+	case "", "text":
+		trace = slog.New(slog.NewTextHandler(traceOutput, nil))
+	case "json":
+		trace = slog.New(slog.NewJSONHandler(traceOutput, nil))
+	default:
+		return fmt.Errorf("invalid -explain-format %q, must be 'text' or 'json'", explainFormat.String())
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ggit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// gitRepo creates a repository in a temporary directory with one commit of the given files, tagged with tags
+func gitRepo(t *testing.T, files map[string]string, tags ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := ggit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sig := &object.Signature{Name: "a", Email: "a@example.com", When: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+	h, err := wt.Commit("commit", &ggit.CommitOptions{Author: sig, AllowEmptyCommits: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range tags {
+		if _, err := repo.CreateTag(tag, h, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// explainRecords runs compute with -explain in JSON format, and returns the trace records
+func explainRecords(t *testing.T, args ...string) []map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	oldTrace, oldOutput := trace, traceOutput
+	traceOutput = &buf
+	t.Cleanup(func() {
+		trace, traceOutput = oldTrace, oldOutput
+	})
+	parseFlags(t, append([]string{"-explain", "-explain-format", "json"}, args...)...)
+	if _, err := compute(); err != nil {
+		t.Fatal(err)
+	}
+	var rv []map[string]any
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var r map[string]any
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		rv = append(rv, r)
+	}
+	return rv
+}
+
+func TestExplain_rejections(t *testing.T) {
+	const gomod = "module example.com/foo\n\ngo 1.22\n"
+	tests := []struct {
+		name  string
+		files map[string]string
+		tags  []string
+		args  []string
+		// want is the attributes of a "tag rejected" record
+		want map[string]any
+	}{
+		{
+			name: "parse failure",
+			tags: []string{"v1.0.0", "release-candidate"},
+			args: []string{"-tags"},
+			want: map[string]any{"tag": "release-candidate", "reason": "parse failure"},
+		},
+		{
+			name: "branch mismatch",
+			tags: []string{"v1.0.0-master", "v1.1.0-other"},
+			args: []string{"-tags", "-branch"},
+			want: map[string]any{"version": "v1.1.0-other", "reason": "branch mismatch", "suffix": "other", "branch": "master"},
+		},
+		{
+			name:  "filter",
+			files: map[string]string{"go.mod": gomod},
+			tags:  []string{"v1.0.0", "v2.0.0"},
+			args:  []string{"-tags", "-gomod"},
+			want:  map[string]any{"version": "v2.0.0", "reason": "filter"},
+		},
+		{
+			name: "pseudo-version, parse failure",
+			tags: []string{"v1.0.0", "release-candidate"},
+			args: []string{"-pseudo"},
+			want: map[string]any{"tag": "release-candidate", "reason": "parse failure"},
+		},
+		{
+			name: "pseudo-version, not a module version",
+			tags: []string{"v1.0.0", "1.1.0"},
+			args: []string{"-pseudo"},
+			want: map[string]any{"tag": "1.1.0", "reason": "not a module version"},
+		},
+		{
+			name: "pseudo-version, pseudo-version tag",
+			tags: []string{"v1.0.0", "v1.0.1-0.20261017120000-abcdef123456"},
+			args: []string{"-pseudo"},
+			want: map[string]any{"tag": "v1.0.1-0.20261017120000-abcdef123456", "reason": "pseudo-version"},
+		},
+		{
+			name:  "pseudo-version, filter",
+			files: map[string]string{"go.mod": gomod},
+			tags:  []string{"v1.0.0", "v2.0.0"},
+			args:  []string{"-pseudo", "-gomod"},
+			want:  map[string]any{"version": "v2.0.0", "reason": "filter"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := gitRepo(t, tt.files, tt.tags...)
+			records := explainRecords(t, append([]string{"-gitdir", dir}, tt.args...)...)
+		records:
+			for _, r := range records {
+				if r["msg"] != "tag rejected" {
+					continue
+				}
+				for k, v := range tt.want {
+					if r[k] != v {
+						continue records
+					}
+				}
+				return
+			}
+			t.Errorf("no 'tag rejected' record with %v in %v", tt.want, records)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/adamhassel/semvergo/pkg/flags"
+	"github.com/adamhassel/semvergo/pkg/maven"
+	"github.com/adamhassel/semvergo/pkg/packaging"
+	"github.com/adamhassel/semvergo/pkg/pep440"
+	"github.com/adamhassel/semvergo/pkg/semver"
+	"github.com/adamhassel/semvergo/pkg/tmpl"
+)
+
+var pkgRelease, pkgEpoch flags.String
+
+// packageRelease returns the Debian revision or RPM release from -pkg-release, defaulting to "1"
+func packageRelease() string {
+	if pkgRelease.IsSet() {
+		return pkgRelease.String()
+	}
+	return "1"
+}
+
+// packageEpoch returns the Debian or RPM epoch from -pkg-epoch, defaulting to 0
+func packageEpoch() (uint, error) {
+	if !pkgEpoch.IsSet() {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(pkgEpoch.String(), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid -pkg-epoch %q, must be a non-negative integer", pkgEpoch.String())
+	}
+	return uint(n), nil
+}
+
+// formats maps -format names to functions rendering a version in that format
+var formats = map[string]func(semver.SemVer) (string, error){
+	"semver": func(sv semver.SemVer) (string, error) {
+		return sv.String(), nil
+	},
+	"pep440": func(sv semver.SemVer) (string, error) {
+		v, err := pep440.FromSemVer(sv)
+		if err != nil {
+			return "", err
+		}
+		return v.String(), nil
+	},
+	"deb": func(sv semver.SemVer) (string, error) {
+		epoch, err := packageEpoch()
+		if err != nil {
+			return "", err
+		}
+		return packaging.DebFromSemVer(sv, epoch, packageRelease()).String(), nil
+	},
+	"rpm": func(sv semver.SemVer) (string, error) {
+		epoch, err := packageEpoch()
+		if err != nil {
+			return "", err
+		}
+		return packaging.RPMFromSemVer(sv, epoch, packageRelease()).String(), nil
+	},
+	"rpm-version": func(sv semver.SemVer) (string, error) {
+		return packaging.RPMFromSemVer(sv, 0, "").Version, nil
+	},
+	"maven": func(sv semver.SemVer) (string, error) {
+		return maven.SnapshotFromSemVer(sv).String(), nil
+	},
+}
+
+// formatNames returns the names of all formats, for flag usage
+func formatNames() string {
+	return strings.Join(slices.Sorted(maps.Keys(formats)), ", ")
+}
+
+// format renders sv in the -format format. The boolean is false if no format is given
+func format(sv semver.SemVer) (string, bool, error) {
+	if !versionFormat.IsSet() {
+		return "", false, nil
+	}
+	if tmpl.IsTemplate(versionFormat.String()) {
+		return formatTemplate(versionFormat.String(), sv)
+	}
+	f, ok := formats[versionFormat.String()]
+	if !ok {
+		return "", false, fmt.Errorf("unknown format %q, must be one of %s", versionFormat.String(), formatNames())
+	}
+	s, err := f(sv)
+	return s, true, err
+}
+
+// formatTemplate renders sv with the template text, dated with the build date
+func formatTemplate(text string, sv semver.SemVer) (string, bool, error) {
+	t, err := tmpl.New(text)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid -format template: %w", err)
+	}
+	date, err := buildDate()
+	if err != nil {
+		return "", false, err
+	}
+	s, err := t.Execute(sv, date)
+	return s, true, err
+}
@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+func TestFormat_packages(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "deb",
+			args: []string{"-v", "1.2.2", "-format", "deb"},
+			want: "1.2.3-1",
+		},
+		{
+			name: "deb with epoch",
+			args: []string{"-v", "1.2.2", "-format", "deb", "-pkg-epoch", "1"},
+			want: "1:1.2.3-1",
+		},
+		{
+			name: "native deb with epoch",
+			args: []string{"-v", "1.2.2", "-suffix", "rc.1", "-format", "deb", "-pkg-epoch", "2", "-pkg-release", ""},
+			want: "2:1.2.3~rc.1",
+		},
+		{
+			name: "rpm with epoch",
+			args: []string{"-v", "1.2.2", "-format", "rpm", "-pkg-epoch", "1", "-pkg-release", "3"},
+			want: "1:1.2.3-3",
+		},
+		{
+			name: "rpm-version ignores the epoch",
+			args: []string{"-v", "1.2.2", "-format", "rpm-version", "-pkg-epoch", "1"},
+			want: "1.2.3",
+		},
+		{
+			name:    "invalid epoch",
+			args:    []string{"-v", "1.2.2", "-format", "deb", "-pkg-epoch", "-1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parseFlags(t, tt.args...)
+			c, err := compute()
+			if err != nil {
+				t.Fatal(err)
+			}
+			sv, err := c.semver()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, _, err := format(sv)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("format() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("format() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/adamhassel/semvergo/pkg/flags"
+	"github.com/adamhassel/semvergo/pkg/gen"
+	git2 "github.com/adamhassel/semvergo/pkg/git"
+)
+
+var genPackage, genOut, ldVar, ldCommitVar, ldDateVar flags.String
+
+func init() {
+	commands["gen go"] = command{
+		flags: func() {
+			flag.Var(&genPackage, "package", "package name of the generated file. Default is 'version'")
+			flag.Var(&genOut, "out", "file to write the generated source to. Default is stdout")
+		},
+		run: genGo,
+	}
+	commands["ldflags"] = command{
+		flags: func() {
+			flag.Var(&ldVar, "var", "fully qualified name of the string variable to set to the version, like 'main.version'. Default is 'main.version'")
+			flag.Var(&ldCommitVar, "commit-var", "fully qualified name of the string variable to set to the git commit hash")
+			flag.Var(&ldDateVar, "date-var", "fully qualified name of the string variable to set to the build date")
+		},
+		run: ldflags,
+	}
+}
+
+// buildInfo returns the version information to embed. The commit is HEAD of the git repository, if there is one. The date is taken from SOURCE_DATE_EPOCH if set, for reproducible builds, and is the current time otherwise.
+func buildInfo(c computed) (gen.Info, error) {
+	sv, err := c.semver()
+	if err != nil {
+		return gen.Info{}, err
+	}
+	date, err := buildDate()
+	if err != nil {
+		return gen.Info{}, err
+	}
+	info := gen.Info{Version: sv, Date: date}
+	if repo, err := openRepo(); err == nil {
+		info.Commit, _, _ = git2.HeadCommit(repo)
+	}
+	return info, nil
+}
+
+// genGo writes a Go source file with version constants
+func genGo(c computed) error {
+	info, err := buildInfo(c)
+	if err != nil {
+		return err
+	}
+	pkg := "version"
+	if genPackage.IsSet() {
+		pkg = genPackage.String()
+	}
+	if !genOut.IsSet() || genOut.String() == "-" {
+		return gen.Go(os.Stdout, pkg, info)
+	}
+	f, err := os.Create(genOut.String())
+	if err != nil {
+		return err
+	}
+	if err := gen.Go(f, pkg, info); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ldflags prints the -X linker flags setting version variables
+func ldflags(c computed) error {
+	info, err := buildInfo(c)
+	if err != nil {
+		return err
+	}
+	vars := gen.Vars{Version: "main.version", Commit: ldCommitVar.String(), Date: ldDateVar.String()}
+	if ldVar.IsSet() {
+		vars.Version = ldVar.String()
+	}
+	s, err := gen.LDFlags(vars, info)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Println(s)
+	return err
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/adamhassel/semvergo/pkg/flags"
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+var incKind, preid, preidBase flags.String
+
+func init() {
+	kinds := make([]string, len(semver.ReleaseTypes))
+	for i, r := range semver.ReleaseTypes {
+		kinds[i] = string(r)
+	}
+	flag.Var(&incKind, "inc", "increment like npm version, one of "+strings.Join(kinds, ", ")+". major, minor and patch release a pending pre-release of the same line, so 1.2.3-rc.1 becomes 1.2.3 with -inc patch. Can't be combined with -major, -minor or -patch")
+	flag.Var(&preid, "preid", "with -inc pre*, the pre-release identifier, like 'rc'. Default is a pre-release of only a number")
+	flag.Var(&preidBase, "preid-base", "with -inc pre*, the first number of a new pre-release, '0', '1', or 'none' to leave it out. Default is '0'")
+}
+
+// incOptions validates the -inc flags against the other flags, and returns the release type and options
+func incOptions() (semver.ReleaseType, semver.IncOptions, error) {
+	var opts semver.IncOptions
+	if !incKind.IsSet() {
+		if preid.IsSet() || preidBase.IsSet() {
+			return "", opts, errors.New("-preid and -preid-base require -inc")
+		}
+		return "", opts, nil
+	}
+	release, err := semver.ParseReleaseType(incKind.String())
+	if err != nil {
+		return "", opts, err
+	}
+	switch {
+	case incMajor.IsSet() || incMinor.IsSet() || incPatch.IsSet():
+		return "", opts, errors.New("-inc can't be combined with -major, -minor or -patch")
+	case pseudo.Bool():
+		return "", opts, errors.New("-inc can't be combined with -pseudo, which isn't incremented")
+	case !release.IsPre() && (preid.IsSet() || preidBase.IsSet()):
+		return "", opts, fmt.Errorf("-preid and -preid-base don't apply to -inc %s", release)
+	case release.IsPre() && suffix.IsSet():
+		return "", opts, fmt.Errorf("-suffix would replace the pre-release made by -inc %s", release)
+	}
+	opts.Identifier = preid.String()
+	switch preidBase.String() {
+	case "", "0":
+	case "1":
+		opts.Base = 1
+	case "none":
+		opts.NoBase = true
+	default:
+		return "", opts, fmt.Errorf("invalid -preid-base %q, must be '0', '1' or 'none'", preidBase.String())
+	}
+	return release, opts, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/adamhassel/semvergo/pkg/flags"
+	git2 "github.com/adamhassel/semvergo/pkg/git"
+	"github.com/adamhassel/semvergo/pkg/scheme"
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+var nightly flags.Bool
+
+func init() {
+	flag.Var(&nightly, "nightly", "compute a nightly version, like 1.5.0-nightly.20261017.1. The version is incremented from the latest release tag, or -v, and the date is today in UTC, or SOURCE_DATE_EPOCH. The counter is one more than that of any nightly tag of the same version and date")
+}
+
+// checkNightly returns an error if -nightly is combined with flags setting the pre-release
+func checkNightly() error {
+	if !nightly.Bool() {
+		return nil
+	}
+	for _, f := range []string{"suffix", "branch", "inc", "pseudo"} {
+		if isSet(f) {
+			return fmt.Errorf("-nightly can't be combined with -%s", f)
+		}
+	}
+	return nil
+}
+
+// nightlyVersion returns sv as a nightly version, counting the nightly tags in the repository
+func nightlyVersion(s scheme.Scheme, sv semver.SemVer) (semver.SemVer, error) {
+	repo, err := openRepo()
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	opts, err := tagOptions(s)
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	date, err := buildDate()
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	return git2.Nightly(repo, opts, sv, date)
+}
+
+// buildDate returns the date of the build, from SOURCE_DATE_EPOCH if set, for reproducible builds, and the current time otherwise
+func buildDate() (time.Time, error) {
+	epoch := os.Getenv("SOURCE_DATE_EPOCH")
+	if epoch == "" {
+		return time.Now(), nil
+	}
+	sec, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH: %w", err)
+	}
+	return time.Unix(sec, 0), nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/adamhassel/semvergo/pkg/flags"
+	"github.com/adamhassel/semvergo/pkg/scheme"
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+var schemeSpec, coerceExtra, labelRank, labelRankFile flags.String
+var coerce flags.Bool
+
+func init() {
+	flag.Var(&coerce, "coerce", "accept loose versions, like 'v1.2', '1.2.3.4' or 'release-2024.1', from -v and tags. Missing minor and patch versions are set to 0, leading zeros are stripped and extra components are moved to the build metadata")
+	flag.Var(&coerceExtra, "coerce-extra", "with -coerce, where to move version components after the patch version, 'build' or 'prerelease'. Default is 'build'")
+	flag.Var(&labelRank, "label-rank", "comma separated pre-release labels in ascending order, like 'dev,staging,rc'. Ranked labels sort by their rank, and above other labels, instead of lexically")
+	flag.Var(&labelRankFile, "label-rank-file", "like -label-rank, but read from a file of labels separated by commas, spaces or newlines. '#' starts a comment")
+	flag.Var(&schemeSpec, "scheme", "version scheme, one of semver, numeric:N (N dot separated numbers), assembly (.NET assembly versions) or calver:FORMAT, like 'calver:YYYY.0M.MICRO'. Default is semver. Semver specific flags and commands are not supported by other schemes")
+}
+
+// semverFlags are the flags that only apply to the semver scheme
+var semverFlags = []string{"prefix", "suffix", "prefix-sep", "suffix-sep", "branch", "gomod", "pseudo", "format", "coerce", "coerce-extra", "inc", "preid", "preid-base", "label-rank", "label-rank-file", "nightly", "branch-counter", "tag-template", "component"}
+
+// versionScheme returns the -scheme version scheme. The semver scheme uses the -prefix-sep and -suffix-sep separators
+func versionScheme() (scheme.Scheme, error) {
+	if !schemeSpec.IsSet() || schemeSpec.String() == "semver" {
+		s := scheme.SemVer{Presep: prefixSeparator.String(), Sufsep: suffixSeparator.String()}
+		if coerce.Bool() {
+			opts, err := coerceOptions()
+			if err != nil {
+				return nil, err
+			}
+			s.Coerce = &opts
+		}
+		r, err := labelRanking()
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			s.Comparator = r.Compare
+		}
+		return s, nil
+	}
+	s, err := scheme.New(schemeSpec.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range semverFlags {
+		if isSet(f) {
+			return nil, fmt.Errorf("-%s requires the semver scheme", f)
+		}
+	}
+	return s, nil
+}
+
+// coerceOptions returns the options for -coerce
+func coerceOptions() (semver.CoerceOptions, error) {
+	switch coerceExtra.String() {
+	case "", "build":
+		return semver.CoerceOptions{Extra: semver.ExtraBuild}, nil
+	case "prerelease":
+		return semver.CoerceOptions{Extra: semver.ExtraPrerelease}, nil
+	}
+	return semver.CoerceOptions{}, fmt.Errorf("invalid -coerce-extra %q, must be 'build' or 'prerelease'", coerceExtra.String())
+}
+
+// labelRanking returns the ranking given by -label-rank or -label-rank-file, or nil if there is none
+func labelRanking() (*semver.Ranking, error) {
+	var r semver.Ranking
+	var err error
+	switch {
+	case labelRank.IsSet() && labelRankFile.IsSet():
+		return nil, fmt.Errorf("-label-rank and -label-rank-file can't both be used")
+	case labelRank.IsSet():
+		r, err = semver.ParseRanking(strings.NewReader(labelRank.String()))
+	case labelRankFile.IsSet():
+		var f *os.File
+		if f, err = os.Open(labelRankFile.String()); err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r, err = semver.ParseRanking(f)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// semver returns the computed version as a semantic version, or an error if another scheme is used
+func (c computed) semver() (semver.SemVer, error) {
+	sv, ok := c.next.(semver.SemVer)
+	if !ok {
+		return semver.SemVer{}, fmt.Errorf("this command requires the semver scheme")
+	}
+	return sv, nil
+}
+
+// isSet reports whether the named flag was given on the command line
+func isSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
@@ -5,22 +5,28 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/go-git/go-git/v5"
 
 	"github.com/adamhassel/semvergo/pkg/flags"
 	git2 "github.com/adamhassel/semvergo/pkg/git"
+	"github.com/adamhassel/semvergo/pkg/gomod"
+	"github.com/adamhassel/semvergo/pkg/output"
+	"github.com/adamhassel/semvergo/pkg/scheme"
 	"github.com/adamhassel/semvergo/pkg/semver"
+	"github.com/adamhassel/semvergo/pkg/updater"
 )
 
-var incMajor, incMinor, incPatch, usetags, usebranch flags.Bool
-var version, prefix, suffix, prefixSeparator, suffixSeparator, gitdir flags.String
+var incMajor, incMinor, incPatch, usetags, usebranch, dryRun, gomodMode, gomodStrict, gomodRewrite, pseudo flags.Bool
+var writeTargets flags.Strings
+var version, prefix, suffix, prefixSeparator, suffixSeparator, gitdir, outputFormat, versionFormat flags.String
 
 func init() {
 	flag.Var(&version, "v", "version string to use")
 	flag.Var(&incMajor, "major", "increment major version")
 	flag.Var(&incMinor, "minor", "increment minor version")
-	flag.Var(&incPatch, "patch", "increment patch version. This is the default if no other increments are set.")
+	flag.Var(&incPatch, "patch", "increment patch version. This is the default if none of -major, -minor and -patch are given. Only one of them can be true")
 	flag.Var(&prefix, "prefix", "prefix to add to semver string")
 	flag.Var(&suffix, "suffix", "suffix to add to semver string")
 	flag.Var(&prefixSeparator, "prefix-sep", "prefix separator used to separate prefix from  semver string. Used both for parsing and constructing. Default is empty")
@@ -29,64 +35,397 @@ func init() {
 	flag.Var(&usetags, "tags", "use latest tag on git repository as version string")
 	flag.Var(&usebranch, "branch", "use branch name as suffix. When used with -tags, the version number used as input is the latest tag suffixed with the branch name")
 	flag.Var(&gitdir, "gitdir", "git directory. Default is current directory.")
+	flag.Var(&writeTargets, "write", "write the version (without prefix) to a manifest file. Supported files are package.json, Cargo.toml, Chart.yaml, pyproject.toml, pom.xml and VERSION. Use 'file:regexp' to replace the 'version' capture group of regexp in any file. May be given multiple times")
+	flag.Var(&dryRun, "dry-run", "with -write or -gomod-rewrite, print a diff of the changes to stderr instead of modifying any files")
+	flag.Var(&gomodMode, "gomod", "Go module mode. Reads go.mod in the git directory, ignores tags that are pseudo-versions or don't match the module path's major version, and warns if the computed major version doesn't match the module path")
+	flag.Var(&gomodStrict, "gomod-strict", "with -gomod, fail instead of warning if the major version doesn't match the module path")
+	flag.Var(&gomodRewrite, "gomod-rewrite", "with -gomod, rewrite the module path in go.mod and all imports of it to match the major version. Only the default command rewrites files")
+	flag.Var(&pseudo, "pseudo", "use the Go pseudo-version of HEAD, based on the latest version tag reachable from it, as the version. No increments are applied. Use with -gomod to respect the module's major version")
+	flag.Var(&versionFormat, "format", "format of the printed and written version, one of "+formatNames()+", or a template like '{{.Prefix}}{{.Major}}.{{.Minor}}{{if .Pre}}-{{.Pre}}{{end}}'. Templates have the fields Prefix, Major, Minor, Patch, Pre, Build, Version, Full, SemVer and Date, and the functions pad, lower, upper, replace, sanitize and date. Default is the semver string")
+	flag.Var(&pkgEpoch, "pkg-epoch", "Debian or RPM epoch used by -format deb and rpm, like '1' for 1:1.2.3-1. Default is no epoch")
+	flag.Var(&pkgRelease, "pkg-release", "Debian revision or RPM release used by -format deb and rpm. Default is '1'. Use an empty string for native Debian packages")
+	flag.Var(&outputFormat, "output", "output format, one of "+strings.Join(output.Formats, ", ")+". Default is 'text'")
+}
+
+// command is a subcommand, like 'gen go'. flags is called before the command line is parsed, to register any flags specific to the command
+type command struct {
+	flags func()
+	run   func(c computed) error
+}
+
+// commands maps subcommand names to commands. The empty name is the default command, which prints the version
+var commands = map[string]command{
+	"": {run: printVersion},
+}
+
+// computed is the result of the version computation, shared by all commands. prev is nil if there was no input version
+type computed struct {
+	scheme       scheme.Scheme
+	prev, next   scheme.Version
+	bump, reason string
 }
 
 func main() {
-	flag.Parse()
+	name, args := subcommand(os.Args[1:])
+	cmd, ok := commands[name]
+	if !ok {
+		log.Fatalf("unknown command %q", name)
+	}
+	if cmd.flags != nil {
+		cmd.flags()
+	}
+	flag.CommandLine.Parse(args)
+	c, err := compute()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := cmd.run(c); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	var sv semver.SemVer
+// subcommand splits args into the subcommand name, which is all leading arguments that are not flags, and the remaining arguments
+func subcommand(args []string) (string, []string) {
+	var words []string
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		words = append(words, args[0])
+		args = args[1:]
+	}
+	return strings.Join(words, " "), args
+}
 
+// workdir returns -gitdir, or the current directory
+func workdir() (string, error) {
+	if dir := gitdir.String(); dir != "" {
+		return dir, nil
+	}
+	return os.Getwd()
+}
+
+// openRepo opens the git repository in -gitdir, or the current directory
+func openRepo() (*git.Repository, error) {
+	dir, err := workdir()
+	if err != nil {
+		return nil, err
+	}
+	return git.PlainOpen(dir)
+}
+
+// checkModule checks that the major version of sv matches the module path. On a mismatch, nothing is done if -gomod-rewrite is given, as printVersion rewrites the module, an error is returned with -gomod-strict, and otherwise a warning is logged
+func checkModule(path string, sv semver.SemVer) error {
+	err := gomod.Check(path, sv)
+	switch {
+	case err == nil, gomodRewrite.Bool():
+		return nil
+	case gomodStrict.Bool():
+		return err
+	}
+	log.Printf("warning: %v", err)
+	return nil
+}
+
+// compute computes the next version from the command line flags
+func compute() (computed, error) {
 	if !suffixSeparator.IsSet() {
 		suffixSeparator.Set("-")
 	}
+	if err := setupTrace(); err != nil {
+		return computed{}, err
+	}
 
-	sv.Presep(prefixSeparator.String())
-	sv.Sufsep(suffixSeparator.String())
+	s, err := versionScheme()
+	if err != nil {
+		return computed{}, err
+	}
 
-	switch {
-	case usetags.Bool():
-		dir := gitdir.String()
+	var moddir, modpath string
+	if gomodMode.Bool() {
+		if moddir, err = workdir(); err != nil {
+			return computed{}, err
+		}
+		if modpath, err = gomod.ReadModulePath(moddir); err != nil {
+			return computed{}, err
+		}
+	}
 
-		if dir == "" {
-			var err error
-			dir, err = os.Getwd()
-			if err != nil {
-				log.Fatal(err)
+	if err := checkNightly(); err != nil {
+		return computed{}, err
+	}
+	if err := checkBranchCounter(); err != nil {
+		return computed{}, err
+	}
+
+	var prev scheme.Version
+	source := "no input version"
+	switch {
+	case pseudo.Bool():
+		repo, err := openRepo()
+		if err != nil {
+			return computed{}, err
+		}
+		var major uint
+		opts := git2.Options{Logger: trace}
+		if gomodMode.Bool() {
+			major, _ = gomod.PathMajor(modpath)
+			compatible := gomod.Compatible(modpath)
+			opts.Filter = func(v scheme.Version) bool {
+				return compatible(v.(semver.SemVer))
 			}
 		}
-		repo, err := git.PlainOpen(dir)
+		if prev, err = git2.PseudoVersion(repo, opts, major); err != nil {
+			return computed{}, err
+		}
+		source = "pseudo-version of HEAD"
+	case (nightly.Bool() || branchCounter.Bool()) && !version.IsSet():
+		repo, err := openRepo()
 		if err != nil {
-			log.Fatal(err)
+			return computed{}, err
 		}
-		sv, err = git2.LatestsGitVersionTag(repo, usebranch.Bool(), suffixSeparator.String())
-	case version.IsSet() && version.String() != "":
-		var err error
-		sv, err = semver.ParseSeparated(version.String(), prefixSeparator.String(), suffixSeparator.String())
+		opts, err := tagOptions(s)
+		if err != nil {
+			return computed{}, err
+		}
+		if prev, err = git2.LatestReleaseTag(repo, opts); err != nil {
+			return computed{}, err
+		}
+		source = "latest release tag"
+	case usetags.Bool():
+		repo, err := openRepo()
 		if err != nil {
-			log.Fatal(err)
+			return computed{}, err
+		}
+		opts, err := tagOptions(s)
+		if err != nil {
+			return computed{}, err
+		}
+		opts.Branch = usebranch.Bool()
+		if gomodMode.Bool() {
+			compatible := gomod.Compatible(modpath)
+			opts.Filter = func(v scheme.Version) bool {
+				return compatible(v.(semver.SemVer))
+			}
 		}
+		if prev, err = git2.LatestVersionTag(repo, opts); err != nil {
+			return computed{}, err
+		}
+		source = "latest git tag"
+		if usebranch.Bool() {
+			source = "latest git tag on branch"
+		}
+	case version.IsSet() && version.String() != "":
+		if sc, ok := s.(scheme.SemVer); ok && sc.Coerce != nil {
+			_, changes, err := semver.CoerceWith(version.String(), *sc.Coerce)
+			if err != nil {
+				return computed{}, err
+			}
+			for _, c := range changes {
+				log.Printf("coercing %s: %s", version.String(), c)
+			}
+		}
+		if prev, err = s.Parse(version.String()); err != nil {
+			return computed{}, err
+		}
+		source = "-v"
+	}
+	if sc, ok := s.(scheme.SemVer); ok && prev == nil {
+		prev = sc.Zero()
+	}
+	if prev != nil {
+		trace.Info("base version selected", "version", s.Format(prev), "source", source)
+	} else {
+		trace.Info("no base version", "source", source)
+	}
+
+	release, incOpts, err := incOptions()
+	if err != nil {
+		return computed{}, err
+	}
+
+	next := prev
+	bump, why := "none", "-pseudo"
+	switch {
+	case release != "":
+		if next, err = semver.Inc(prev.(semver.SemVer), release, incOpts); err != nil {
+			return computed{}, err
+		}
+		bump, why = string(release), "-inc"
+	case !pseudo.Bool():
+		if next, bump, why, err = increment(s, prev); err != nil {
+			return computed{}, err
+		}
+	}
+	trace.Info("increment applied", "bump", bump, "source", why, "version", s.Format(next))
+
+	if sv, ok := next.(semver.SemVer); ok {
+		if suffix.IsSet() {
+			sv.Suffix(suffix.String())
+			trace.Info("suffix overridden", "suffix", suffix.String(), "source", "-suffix", "version", sv.String())
+		}
+		if prefix.IsSet() {
+			sv.Prefix(prefix.String())
+			trace.Info("prefix overridden", "prefix", prefix.String(), "source", "-prefix", "version", sv.String())
+		}
+		if nightly.Bool() {
+			if sv, err = nightlyVersion(s, sv); err != nil {
+				return computed{}, err
+			}
+			trace.Info("pre-release overridden", "source", "-nightly", "version", sv.String())
+		}
+		if branchCounter.Bool() {
+			if sv, err = branchCounterVersion(s, sv); err != nil {
+				return computed{}, err
+			}
+			trace.Info("pre-release overridden", "source", "-branch-counter", "version", sv.String())
+		}
+		if gomodMode.Bool() {
+			if err := checkModule(modpath, sv); err != nil {
+				return computed{}, err
+			}
+		}
+		next = sv
+	}
+
+	base := "none"
+	if prev != nil {
+		base = s.Format(prev)
+	}
+	reason := fmt.Sprintf("base version %s from %s, %s increment (%s)", base, source, bump, why)
+	trace.Info("version computed", "version", s.Format(next), "reason", reason)
+	return computed{scheme: s, prev: prev, next: next, bump: bump, reason: reason}, nil
+}
+
+// tagScheme returns s for parsing tags, which are parsed without a prefix separator
+func tagScheme(s scheme.Scheme) scheme.Scheme {
+	if sc, ok := s.(scheme.SemVer); ok {
+		sc.Presep = ""
+		return sc
 	}
+	return s
+}
 
-	if incMajor.IsSet() && incMajor.Bool() {
-		sv.IncrementMajor()
+// increment bumps v in s as given by the -major, -minor and -patch flags, returning the level incremented and the flag causing it. Only one of the flags may be true, and patch is incremented if none of them are given
+func increment(s scheme.Scheme, v scheme.Version) (next scheme.Version, bump, why string, err error) {
+	var levels []scheme.Level
+	var set []string
+	for _, f := range []struct {
+		flag  *flags.Bool
+		level scheme.Level
+		bump  string
+	}{
+		{&incMajor, scheme.Major, "major"},
+		{&incMinor, scheme.Minor, "minor"},
+		{&incPatch, scheme.Patch, "patch"},
+	} {
+		if f.flag.Bool() {
+			levels = append(levels, f.level)
+			set = append(set, "-"+f.bump)
+			bump, why = f.bump, "-"+f.bump
+		}
+	}
+	switch {
+	case len(set) > 1:
+		return nil, "", "", fmt.Errorf("only one of -major, -minor and -patch can be given, got %s", strings.Join(set, ", "))
+	case len(set) == 1:
+	case !incMajor.IsSet() && !incMinor.IsSet() && !incPatch.IsSet():
+		levels = append(levels, scheme.Patch)
+		bump, why = "patch", "default"
+	default:
+		bump, why = "none", "all increments disabled"
+	}
+
+	next = v
+	for _, l := range levels {
+		if next, err = s.Bump(next, l); err != nil {
+			return nil, "", "", err
+		}
+	}
+	if next == nil {
+		return nil, "", "", fmt.Errorf("no input version, and no increment")
+	}
+	return next, bump, why, nil
+}
+
+// printVersion is the default command. It updates any -write targets, and prints the version in the -output format
+func printVersion(c computed) error {
+	r := output.NewSchemeResult(c.scheme, c.prev, c.next, c.bump, c.reason)
+	if tag, ok, err := templateTag(c); err != nil {
+		return err
+	} else if ok {
+		r.Tag = tag
+	}
+	// manifests don't use prefixes
+	version := r.Version
+	if sv, ok := c.next.(semver.SemVer); ok {
+		formatted, ok, err := format(sv)
+		if err != nil {
+			return err
+		}
+		sv.Prefix("")
+		version = sv.String()
+		if ok {
+			r.Formatted = formatted
+			version = formatted
+		}
 	}
-	if incMinor.IsSet() && incMinor.Bool() {
-		sv.IncrementMinor()
+	if err := rewriteModule(c); err != nil {
+		return err
 	}
-	if incPatch.IsSet() && incPatch.Bool() {
-		sv.IncrementPatch()
+	if err := writeFiles(version, writeTargets.Strings(), dryRun.Bool()); err != nil {
+		return err
 	}
+	return write(outputFormat.String(), r)
+}
 
-	if !incMajor.IsSet() && !incMinor.IsSet() {
-		sv.IncrementPatch()
+// write writes r to stdout, or to $GITHUB_OUTPUT for the GitHub format
+func write(format string, r output.Result) error {
+	if format != output.GitHub {
+		return output.Write(os.Stdout, format, r)
+	}
+	f, err := output.OpenGitHubOutput()
+	if err != nil {
+		return err
 	}
+	defer f.Close()
+	return output.Write(f, format, r)
+}
 
-	if suffix.IsSet() {
-		sv.Suffix(suffix.String())
+// rewriteModule rewrites the module path in go.mod and all imports of it to match the major version of the computed version, with -gomod-rewrite. With -dry-run, a diff of the changes is printed to stderr instead
+func rewriteModule(c computed) error {
+	sv, ok := c.next.(semver.SemVer)
+	if !gomodMode.Bool() || !gomodRewrite.Bool() || !ok {
+		return nil
+	}
+	dir, err := workdir()
+	if err != nil {
+		return err
+	}
+	path, err := gomod.ReadModulePath(dir)
+	if err != nil {
+		return err
 	}
-	if prefix.IsSet() {
-		sv.Prefix(prefix.String())
+	if gomod.Check(path, sv) == nil {
+		return nil
 	}
+	newPath := gomod.MajorPath(path, sv.Major())
+	log.Printf("rewriting module path %s to %s", path, newPath)
+	return gomod.Rewrite(dir, path, newPath, dryRun.Bool(), os.Stderr)
+}
 
-	fmt.Printf(sv.String())
+// writeFiles writes version to all targets. Diffs show paths relative to the repository
+func writeFiles(version string, targets []string, dryRun bool) error {
+	dir, err := workdir()
+	if err != nil {
+		return err
+	}
+	for _, spec := range targets {
+		t, err := updater.ParseTarget(spec)
+		if err != nil {
+			return err
+		}
+		t.Dir = dir
+		if err := t.Apply(version, dryRun, os.Stderr); err != nil {
+			return err
+		}
+	}
+	return nil
 }
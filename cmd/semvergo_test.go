@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// parseFlags resets the semvergo flags and parses args, like main does
+func parseFlags(t *testing.T, args ...string) {
+	t.Helper()
+	reset := func() {
+		flag.CommandLine.VisitAll(func(f *flag.Flag) {
+			if !strings.HasPrefix(f.Name, "test.") {
+				reflect.ValueOf(f.Value).Elem().SetZero()
+			}
+		})
+	}
+	reset()
+	t.Cleanup(reset)
+	if err := flag.CommandLine.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIncrement(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "default",
+			args: []string{"-v", "1.2.3"},
+			want: "1.2.4",
+		},
+		{
+			name: "major",
+			args: []string{"-v", "1.2.3", "-major"},
+			want: "2.0.0",
+		},
+		{
+			name: "minor",
+			args: []string{"-v", "1.2.3", "-minor"},
+			want: "1.3.0",
+		},
+		{
+			name: "patch",
+			args: []string{"-v", "1.2.3", "-patch"},
+			want: "1.2.4",
+		},
+		{
+			name: "other increments false",
+			args: []string{"-v", "1.2.3", "-major=false", "-minor"},
+			want: "1.3.0",
+		},
+		{
+			name: "patch false",
+			args: []string{"-v", "1.2.3", "-patch=false"},
+			want: "1.2.3",
+		},
+		{
+			name: "all false",
+			args: []string{"-v", "1.2.3", "-major=false", "-minor=false", "-patch=false"},
+			want: "1.2.3",
+		},
+		{
+			name:    "minor and patch",
+			args:    []string{"-v", "1.2.3", "-minor", "-patch"},
+			wantErr: true,
+		},
+		{
+			name:    "major and minor",
+			args:    []string{"-v", "1.2.3", "-major", "-minor"},
+			wantErr: true,
+		},
+		{
+			name:    "no version and no increment",
+			args:    []string{"-scheme", "numeric:3", "-patch=false"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parseFlags(t, tt.args...)
+			c, err := compute()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := c.scheme.Format(c.next); got != tt.want {
+				t.Errorf("compute() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
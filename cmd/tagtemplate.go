@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/adamhassel/semvergo/pkg/flags"
+	git2 "github.com/adamhassel/semvergo/pkg/git"
+	"github.com/adamhassel/semvergo/pkg/scheme"
+)
+
+var tagTemplate, component flags.String
+
+func init() {
+	flag.Var(&tagTemplate, "tag-template", "the form of version tag names, like 'release-{{version}}' or '{{component}}@v{{version}}'. Only tags matching it with a semantic version are read, and the printed tag has this form")
+	flag.Var(&component, "component", "the component of a -tag-template with a {{component}}. Only its tags are read")
+}
+
+// parseTagTemplate returns the -tag-template, or nil if not set
+func parseTagTemplate() (*git2.TagTemplate, error) {
+	if !tagTemplate.IsSet() {
+		if component.IsSet() {
+			return nil, fmt.Errorf("-component requires -tag-template")
+		}
+		return nil, nil
+	}
+	t, err := git2.ParseTagTemplate(tagTemplate.String())
+	if err != nil {
+		return nil, err
+	}
+	if t.HasComponent() && component.String() == "" {
+		return nil, fmt.Errorf("-tag-template %s requires -component", t)
+	}
+	if !t.HasComponent() && component.IsSet() {
+		return nil, fmt.Errorf("-tag-template %s has no {{component}}", t)
+	}
+	return t, nil
+}
+
+// tagOptions returns the options for reading the version tags of the repository in s
+func tagOptions(s scheme.Scheme) (git2.Options, error) {
+	t, err := parseTagTemplate()
+	if err != nil {
+		return git2.Options{}, err
+	}
+	return git2.Options{Scheme: tagScheme(s), Template: t, Component: component.String(), Logger: trace}, nil
+}
+
+// templateTag returns the tag name of the computed version in the -tag-template form. ok is false if -tag-template is not set
+func templateTag(c computed) (tag string, ok bool, err error) {
+	t, err := parseTagTemplate()
+	if err != nil || t == nil {
+		return "", false, err
+	}
+	sv, err := c.semver()
+	if err != nil {
+		return "", false, err
+	}
+	sv.Prefix("")
+	return t.Tag(component.String(), sv.String()), true, nil
+}
@@ -1,6 +1,6 @@
 module github.com/adamhassel/semvergo
 
-go 1.22.3
+go 1.23.0
 
 require (
 	github.com/go-git/go-git v4.7.0+incompatible
@@ -0,0 +1,82 @@
+package docker
+
+import (
+	"fmt"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+// MaxTagLen is the maximum length of an OCI tag
+const MaxTagLen = 128
+
+// Latest is the name of the tag following the highest release
+const Latest = "latest"
+
+// Options controls the generated tags
+type Options struct {
+	// Prefixes are prepended to every tag. Each prefix yields a full set of tags. No prefixes is the same as only the empty prefix
+	Prefixes []string
+	// NoLatest disables the 'latest' tag
+	NoLatest bool
+}
+
+// Tags returns the image tags to publish for v, given the existing versions. The full version is always included. For releases, the floating 'major.minor', 'major' and 'latest' tags are included, if v is the highest release in that line, counting both v and existing. Pre-releases never move floating tags. Any prefix on v itself is dropped, and tags are sanitized to valid OCI tags.
+func Tags(v semver.SemVer, existing []semver.SemVer, opts Options) []string {
+	v.Prefix("")
+	names := []string{v.String()}
+	if v.Prerelease() == "" {
+		highestMinor, highestMajor, highest := true, true, true
+		for _, e := range existing {
+			if e.Prerelease() != "" || semver.Compare(e, v) <= 0 {
+				continue
+			}
+			highest = false
+			if e.Major() == v.Major() {
+				highestMajor = false
+				if e.Minor() == v.Minor() {
+					highestMinor = false
+				}
+			}
+		}
+		if highestMinor {
+			names = append(names, fmt.Sprintf("%d.%d", v.Major(), v.Minor()))
+		}
+		if highestMajor {
+			names = append(names, fmt.Sprintf("%d", v.Major()))
+		}
+		if highest && !opts.NoLatest {
+			names = append(names, Latest)
+		}
+	}
+
+	prefixes := opts.Prefixes
+	if len(prefixes) == 0 {
+		prefixes = []string{""}
+	}
+	var rv []string
+	for _, p := range prefixes {
+		for _, n := range names {
+			rv = append(rv, Sanitize(p+n))
+		}
+	}
+	return rv
+}
+
+// Sanitize turns s into a valid OCI tag, matching [a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}. Invalid characters, like the '+' of build metadata, are replaced by '-', a leading '.' or '-' is replaced by '_', and the tag is truncated to MaxTagLen characters
+func Sanitize(s string) string {
+	b := []byte(s)
+	if len(b) > MaxTagLen {
+		b = b[:MaxTagLen]
+	}
+	for i, c := range b {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_':
+		case i == 0:
+			b[i] = '_'
+		case c == '.' || c == '-':
+		default:
+			b[i] = '-'
+		}
+	}
+	return string(b)
+}
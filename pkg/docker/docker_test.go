@@ -0,0 +1,107 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+func parse(t *testing.T, s ...string) []semver.SemVer {
+	t.Helper()
+	var rv []semver.SemVer
+	for _, v := range s {
+		sv, err := semver.ParseSeparated(v, "", "-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rv = append(rv, sv)
+	}
+	return rv
+}
+
+func TestTags(t *testing.T) {
+	existing := []string{"v1.1.0", "v1.2.2", "v1.2.5", "v1.3.0", "v2.0.0", "v2.1.0-rc.1"}
+	tests := []struct {
+		name    string
+		version string
+		opts    Options
+		want    []string
+	}{
+		{
+			name:    "highest overall",
+			version: "v2.0.1",
+			want:    []string{"2.0.1", "2.0", "2", "latest"},
+		},
+		{
+			name:    "higher pre-releases don't count",
+			version: "v2.0.1",
+			opts:    Options{NoLatest: true},
+			want:    []string{"2.0.1", "2.0", "2"},
+		},
+		{
+			name:    "highest in minor line only",
+			version: "v1.2.6",
+			want:    []string{"1.2.6", "1.2"},
+		},
+		{
+			name:    "highest in major line",
+			version: "v1.4.0",
+			want:    []string{"1.4.0", "1.4", "1"},
+		},
+		{
+			name:    "backport",
+			version: "v1.2.4",
+			want:    []string{"1.2.4"},
+		},
+		{
+			name:    "pre-release",
+			version: "v3.0.0-rc.1",
+			want:    []string{"3.0.0-rc.1"},
+		},
+		{
+			name:    "build metadata and prefixes",
+			version: "v3.0.0+build.7",
+			opts:    Options{Prefixes: []string{"", "alpine-"}},
+			want:    []string{"3.0.0-build.7", "3.0", "3", "latest", "alpine-3.0.0-build.7", "alpine-3.0", "alpine-3", "alpine-latest"},
+		},
+		{
+			name:    "existing version",
+			version: "v2.0.0",
+			want:    []string{"2.0.0", "2.0", "2", "latest"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Tags(parse(t, tt.version)[0], parse(t, existing...), tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	long := make([]byte, 200)
+	for i := range long {
+		long[i] = 'a'
+	}
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "valid", s: "1.2.3-rc.1_x", want: "1.2.3-rc.1_x"},
+		{name: "build metadata", s: "1.2.3+build", want: "1.2.3-build"},
+		{name: "leading dot", s: ".1", want: "_1"},
+		{name: "slash", s: "feature/foo", want: "feature-foo"},
+		{name: "too long", s: string(long), want: string(long[:MaxTagLen])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.s); got != tt.want {
+				t.Errorf("Sanitize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package flags
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 type String struct {
@@ -54,3 +55,27 @@ func (bf *Bool) Bool() bool {
 func (bf *Bool) IsSet() bool {
 	return bf.set
 }
+
+// Strings is a flag that can be given multiple times, collecting all values
+type Strings struct {
+	set    bool
+	values []string
+}
+
+func (sf *Strings) Set(x string) error {
+	sf.values = append(sf.values, x)
+	sf.set = true
+	return nil
+}
+
+func (sf *Strings) String() string {
+	return strings.Join(sf.values, ",")
+}
+
+func (sf *Strings) Strings() []string {
+	return sf.values
+}
+
+func (sf *Strings) IsSet() bool {
+	return sf.set
+}
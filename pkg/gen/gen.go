@@ -0,0 +1,117 @@
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"go/format"
+	"go/token"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+var ErrInvalidPackage = errors.New("invalid package name")
+var ErrInvalidVar = errors.New("invalid variable name, must be of the form 'import/path.name'")
+
+// Info is the version information to embed in a binary
+type Info struct {
+	Version semver.SemVer
+	Commit  string
+	Date    time.Time
+}
+
+var goTemplate = template.Must(template.New("go").Funcs(template.FuncMap{"quote": strconv.Quote}).Parse(`// Code generated by semvergo. DO NOT EDIT.
+
+package {{.Package}}
+
+const (
+	// Version is the full version string
+	Version = {{quote .Version.String}}
+	// Major is the major version
+	Major = {{.Version.Major}}
+	// Minor is the minor version
+	Minor = {{.Version.Minor}}
+	// Patch is the patch version
+	Patch = {{.Version.Patch}}
+	// Prerelease is the pre-release label, if any
+	Prerelease = {{quote .Version.Prerelease}}
+	// Build is the build metadata, if any
+	Build = {{quote .Version.Build}}
+	// Commit is the git commit the version was computed from
+	Commit = {{quote .Commit}}
+	// Date is the build date, in RFC 3339 format
+	Date = {{quote .Date}}
+)
+`))
+
+// Go writes a Go source file declaring pkg, with constants describing info
+func Go(w io.Writer, pkg string, info Info) error {
+	if !token.IsIdentifier(pkg) {
+		return ErrInvalidPackage
+	}
+	var b bytes.Buffer
+	err := goTemplate.Execute(&b, struct {
+		Package string
+		Version semver.SemVer
+		Commit  string
+		Date    string
+	}{pkg, info.Version, info.Commit, date(info.Date)})
+	if err != nil {
+		return err
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// Vars are the fully qualified names (like 'main.version') of string variables to set with -ldflags. Empty names are skipped.
+type Vars struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// LDFlags returns the linker -X flags setting the variables in vars to the values in info, suitable for 'go build -ldflags'
+func LDFlags(vars Vars, info Info) (string, error) {
+	var flags []string
+	for _, v := range []struct{ name, value string }{
+		{vars.Version, info.Version.String()},
+		{vars.Commit, info.Commit},
+		{vars.Date, date(info.Date)},
+	} {
+		if v.name == "" {
+			continue
+		}
+		if i := strings.LastIndex(v.name, "."); i < 1 || !token.IsIdentifier(v.name[i+1:]) {
+			return "", ErrInvalidVar
+		}
+		flags = append(flags, "-X "+quoteArg(v.name+"="+v.value))
+	}
+	return strings.Join(flags, " "), nil
+}
+
+// date formats t as RFC 3339 in UTC, or returns the empty string for the zero time
+func date(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// quoteArg quotes s, if necessary, so that the go command's flag splitting keeps it as one argument. The go command does not support escapes within quotes.
+func quoteArg(s string) string {
+	switch {
+	case !strings.ContainsAny(s, " \t\n'\""):
+		return s
+	case !strings.Contains(s, "'"):
+		return "'" + s + "'"
+	}
+	return `"` + s + `"`
+}
@@ -0,0 +1,100 @@
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+func testInfo(t *testing.T) Info {
+	v, err := semver.ParseSeparated("v1.2.3-rc.1+build.5", "", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Info{
+		Version: v,
+		Commit:  "0123456789abcdef0123456789abcdef01234567",
+		Date:    time.Date(2026, 10, 17, 12, 0, 0, 0, time.FixedZone("CEST", 7200)),
+	}
+}
+
+func TestGo(t *testing.T) {
+	want := `// Code generated by semvergo. DO NOT EDIT.
+
+package version
+
+const (
+	// Version is the full version string
+	Version = "v1.2.3-rc.1+build.5"
+	// Major is the major version
+	Major = 1
+	// Minor is the minor version
+	Minor = 2
+	// Patch is the patch version
+	Patch = 3
+	// Prerelease is the pre-release label, if any
+	Prerelease = "rc.1"
+	// Build is the build metadata, if any
+	Build = "build.5"
+	// Commit is the git commit the version was computed from
+	Commit = "0123456789abcdef0123456789abcdef01234567"
+	// Date is the build date, in RFC 3339 format
+	Date = "2026-10-17T10:00:00Z"
+)
+`
+	var b bytes.Buffer
+	if err := Go(&b, "version", testInfo(t)); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != want {
+		t.Errorf("Go() got = %s, want %s", b.String(), want)
+	}
+	if err := Go(&b, "not-a-package", testInfo(t)); !errors.Is(err, ErrInvalidPackage) {
+		t.Errorf("Go() error = %v, wantErr %v", err, ErrInvalidPackage)
+	}
+}
+
+func TestLDFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		vars    Vars
+		want    string
+		wantErr error
+	}{
+		{
+			name: "version only",
+			vars: Vars{Version: "main.version"},
+			want: "-X main.version=v1.2.3-rc.1+build.5",
+		},
+		{
+			name: "all",
+			vars: Vars{Version: "github.com/foo/bar/version.Version", Commit: "main.commit", Date: "main.date"},
+			want: "-X github.com/foo/bar/version.Version=v1.2.3-rc.1+build.5 -X main.commit=0123456789abcdef0123456789abcdef01234567 -X main.date=2026-10-17T10:00:00Z",
+		},
+		{
+			name:    "no package",
+			vars:    Vars{Version: "version"},
+			wantErr: ErrInvalidVar,
+		},
+		{
+			name:    "invalid name",
+			vars:    Vars{Version: "main.1version"},
+			wantErr: ErrInvalidVar,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LDFlags(tt.vars, testInfo(t))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("LDFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("LDFlags() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
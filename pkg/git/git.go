@@ -1,11 +1,19 @@
 package git
 
 import (
+	"errors"
+	"io"
 	"log"
+	"log/slog"
+	"strings"
+	"time"
 
 	ggit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 
+	"github.com/adamhassel/semvergo/pkg/gomod"
+	"github.com/adamhassel/semvergo/pkg/scheme"
 	"github.com/adamhassel/semvergo/pkg/semver"
 )
 
@@ -33,26 +41,236 @@ func branchTags(repo *ggit.Repository) []string {
 	return rv
 }
 
-// LatestsGitVersionTag returns the latest version tag from the repository's tags. If `branch` is true, will only look at version tags suffixed with the branch name. sufsep is the suffix separator.
-func LatestsGitVersionTag(repo *ggit.Repository, branch bool, sufsep string) (semver.SemVer, error) {
-	tags := branchTags(repo)
+var ErrBranchScheme = errors.New("branch tags require the semver scheme")
+var ErrSemVerScheme = errors.New("this requires the semver scheme")
+
+// Options controls which tags are considered when looking for the latest version tag
+type Options struct {
+	// Scheme parses and orders the tags. Default is semver
+	Scheme scheme.Scheme
+	// Branch, if true, only considers version tags suffixed with the current branch name. It requires the semver scheme
+	Branch bool
+	// Filter, if not nil, only considers versions for which it returns true
+	Filter func(scheme.Version) bool
+	// Template, if not nil, is the form of tag names. Only matching tags are considered, and their version is parsed with Scheme. Otherwise tags are parsed as versions with any prefix
+	Template *TagTemplate
+	// Component, with a Template with a {{component}}, only considers tags of this component. If empty, tags of any component are considered
+	Component string
+	// Logger, if not nil, traces which tags are scanned, and why tags are rejected
+	Logger *slog.Logger
+}
+
+// logger returns o.Logger, or a logger discarding everything
+func (o Options) logger() *slog.Logger {
+	if o.Logger == nil {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return o.Logger
+}
+
+// semver returns the scheme of o, which must be semver
+func (o Options) semver() (scheme.SemVer, error) {
+	if o.Scheme == nil {
+		return scheme.SemVer{}, nil
+	}
+	s, ok := o.Scheme.(scheme.SemVer)
+	if !ok {
+		return scheme.SemVer{}, ErrSemVerScheme
+	}
+	return s, nil
+}
+
+// LatestsGitVersionTag returns the latest version tag from the repository's tags. If `branch` is true, will only look at version tags suffixed with the branch name. sufsep is the suffix separator. Tags are compared with semver.Compare, or with cmp if given, like the Compare method of a semver.Ranking
+func LatestsGitVersionTag(repo *ggit.Repository, branch bool, sufsep string, cmp ...semver.Comparator) (semver.SemVer, error) {
+	var c semver.Comparator
+	if len(cmp) > 0 {
+		c = cmp[0]
+	}
+	v, err := LatestVersionTag(repo, Options{Scheme: scheme.SemVer{Sufsep: sufsep, Comparator: c}, Branch: branch})
+	if err != nil || v == nil {
+		return semver.SemVer{}, err
+	}
+	return v.(semver.SemVer), nil
+}
+
+// LatestVersionTag returns the latest version tag from the repository's tags, as selected by opts, or nil if there is none. With opts.Branch, the version is suffixed with the branch name, and 0.0.0 is returned if there is no tag
+func LatestVersionTag(repo *ggit.Repository, opts Options) (scheme.Version, error) {
+	s := opts.Scheme
+	if s == nil {
+		s = scheme.SemVer{}
+	}
+	sc, isSemVer := s.(scheme.SemVer)
+	if opts.Branch && !isSemVer {
+		return nil, ErrBranchScheme
+	}
 	thisbranch := currentBranch(repo)
-	var vs []semver.SemVer
-	for _, tag := range tags {
-		v, err := semver.ParseSeparated(tag, "", sufsep)
-		if err != nil {
-			continue
+	logger := opts.logger()
+	var vs []scheme.Version
+	opts.Scheme = s
+	for _, v := range VersionTags(repo, opts) {
+		if opts.Branch {
+			if _, suffix := v.(semver.SemVer).PreSuffix(); suffix != thisbranch {
+				logger.Info("tag rejected", "version", s.Format(v), "reason", "branch mismatch", "suffix", suffix, "branch", thisbranch)
+				continue
+			}
 		}
-		_, suffix := v.PreSuffix()
-		if branch && suffix != thisbranch {
+		if opts.Filter != nil && !opts.Filter(v) {
+			logger.Info("tag rejected", "version", s.Format(v), "reason", "filter")
 			continue
 		}
 		vs = append(vs, v)
 	}
-	rv := semver.MaxSlice(vs)
-	if branch {
-		rv.Sufsep(sufsep)
-		rv.Suffix(thisbranch)
+	rv := scheme.Max(s, vs)
+	if rv != nil {
+		logger.Info("latest tag selected", "version", s.Format(rv), "candidates", len(vs))
+	} else {
+		logger.Info("no version tag found", "candidates", 0)
+	}
+	if opts.Branch {
+		sv := sc.Zero()
+		if rv != nil {
+			sv = rv.(semver.SemVer)
+		}
+		sv.Suffix(thisbranch)
+		rv = sv
 	}
 	return rv, nil
 }
+
+// BranchLabel returns the name of the current branch as a pre-release identifier, with any characters other than alphanumerics and '-' replaced by '-', so feature/login becomes feature-login
+func BranchLabel(repo *ggit.Repository) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return '-'
+	}, currentBranch(repo))
+}
+
+// BranchCounter returns base with a pre-release of the current branch and a counter, like 1.4.0-feature-login.1. The counter is one more than the highest counter of the repository's tags of the same version and branch, as selected by opts, so the first build of a branch is 1. opts.Scheme must be semver. See BranchLabel for the branch identifier. Any build metadata of base is dropped
+func BranchCounter(repo *ggit.Repository, opts Options, base semver.SemVer) (semver.SemVer, error) {
+	return withCounter(repo, opts, base, BranchLabel(repo))
+}
+
+// VersionTags returns all tags in the repository that match opts.Template and opts.Component, if set, and parse as versions in opts.Scheme. opts.Filter and opts.Branch are ignored
+func VersionTags(repo *ggit.Repository, opts Options) []scheme.Version {
+	logger := opts.logger()
+	var vs []scheme.Version
+	for _, tag := range branchTags(repo) {
+		logger.Info("tag scanned", "tag", tag)
+		version := tag
+		if opts.Template != nil {
+			component, v, ok := opts.Template.Match(tag)
+			if !ok {
+				logger.Info("tag rejected", "tag", tag, "reason", "template mismatch", "template", opts.Template.String())
+				continue
+			}
+			if opts.Component != "" && component != opts.Component {
+				logger.Info("tag rejected", "tag", tag, "reason", "component mismatch", "component", component)
+				continue
+			}
+			version = v
+		}
+		v, err := opts.Scheme.Parse(version)
+		if err != nil {
+			logger.Info("tag rejected", "tag", tag, "reason", "parse failure", "error", err)
+			continue
+		}
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+// HeadCommit returns the hash and committer time of the commit HEAD points to
+func HeadCommit(repo *ggit.Repository) (string, time.Time, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	c, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return c.Hash.String(), c.Committer.When, nil
+}
+
+// tagCommits returns the names of all tags in the repository, by the commit they point to. Annotated tags are peeled to their commit
+func tagCommits(repo *ggit.Repository) (map[plumbing.Hash][]string, error) {
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	rv := make(map[plumbing.Hash][]string)
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		hash := ref.Hash()
+		if tag, err := repo.TagObject(hash); err == nil {
+			c, err := tag.Commit()
+			if err != nil {
+				// tags of trees or blobs are not versions
+				return nil
+			}
+			hash = c.Hash
+		}
+		rv[hash] = append(rv[hash], ref.Name().Short())
+		return nil
+	})
+	return rv, err
+}
+
+// PseudoVersion returns the Go pseudo-version of HEAD. It is based on the highest version tag on an ancestor of HEAD for which opts.Filter returns true (all tags, if it is nil). Only tags of the form vX.Y.Z[-pre][+build] that are not pseudo-versions themselves are considered, like the go command does, so the other fields of opts are ignored. major is the major version of the module, used if there are no tags
+func PseudoVersion(repo *ggit.Repository, opts Options, major uint) (semver.SemVer, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	tagged, err := tagCommits(repo)
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	commits, err := repo.Log(&ggit.LogOptions{From: head.Hash()})
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	logger := opts.logger()
+	var headCommit *object.Commit
+	var vs []semver.SemVer
+	err = commits.ForEach(func(c *object.Commit) error {
+		if headCommit == nil {
+			headCommit = c
+		}
+		for _, tag := range tagged[c.Hash] {
+			logger.Info("tag scanned", "tag", tag)
+			v, err := semver.ParseSeparated(tag, "", "-")
+			if err != nil {
+				logger.Info("tag rejected", "tag", tag, "reason", "parse failure", "error", err)
+				continue
+			}
+			if p, _ := v.PreSuffix(); p != "v" || v.String() != tag {
+				logger.Info("tag rejected", "tag", tag, "reason", "not a module version")
+				continue
+			}
+			if gomod.IsPseudo(v) {
+				logger.Info("tag rejected", "tag", tag, "reason", "pseudo-version")
+				continue
+			}
+			if opts.Filter != nil && !opts.Filter(v) {
+				logger.Info("tag rejected", "version", tag, "reason", "filter")
+				continue
+			}
+			vs = append(vs, v)
+		}
+		return nil
+	})
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	var older *semver.SemVer
+	if len(vs) > 0 {
+		latest := semver.MaxSlice(vs)
+		older = &latest
+		logger.Info("latest tag selected", "version", latest.String(), "candidates", len(vs))
+	} else {
+		logger.Info("no version tag found", "candidates", 0)
+	}
+	return gomod.PseudoVersion(major, older, headCommit.Committer.When, headCommit.Hash.String()), nil
+}
@@ -0,0 +1,279 @@
+package git
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	ggit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+
+	"github.com/adamhassel/semvergo/pkg/scheme"
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+// testRepo is an in-memory repository without a worktree
+type testRepo struct {
+	t    *testing.T
+	repo *ggit.Repository
+	tree plumbing.Hash
+	when time.Time
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	repo, err := ggit.Init(memory.NewStorage(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &testRepo{t: t, repo: repo, when: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+	r.tree = r.store(&object.Tree{})
+	return r
+}
+
+// store writes o to the repository, returning its hash
+func (r *testRepo) store(o interface {
+	Encode(plumbing.EncodedObject) error
+}) plumbing.Hash {
+	r.t.Helper()
+	obj := r.repo.Storer.NewEncodedObject()
+	if err := o.Encode(obj); err != nil {
+		r.t.Fatal(err)
+	}
+	h, err := r.repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		r.t.Fatal(err)
+	}
+	return h
+}
+
+// commit adds a commit on branch with the given parents, which becomes the branch's head, and checks out branch
+func (r *testRepo) commit(branch string, parents ...plumbing.Hash) plumbing.Hash {
+	r.t.Helper()
+	r.when = r.when.Add(time.Hour)
+	sig := object.Signature{Name: "a", Email: "a@example.com", When: r.when}
+	h := r.store(&object.Commit{Author: sig, Committer: sig, Message: "commit", TreeHash: r.tree, ParentHashes: parents})
+	r.checkout(branch)
+	if err := r.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), h)); err != nil {
+		r.t.Fatal(err)
+	}
+	return h
+}
+
+// checkout points HEAD to branch
+func (r *testRepo) checkout(branch string) {
+	r.t.Helper()
+	if err := r.repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName(branch))); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+// tag adds lightweight tags of h
+func (r *testRepo) tag(h plumbing.Hash, names ...string) {
+	r.t.Helper()
+	for _, name := range names {
+		if _, err := r.repo.CreateTag(name, h, nil); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+}
+
+// annotatedTag adds an annotated tag of h
+func (r *testRepo) annotatedTag(h plumbing.Hash, name string) {
+	r.t.Helper()
+	opts := &ggit.CreateTagOptions{Tagger: &object.Signature{Name: "a", Email: "a@example.com", When: r.when}, Message: name}
+	if _, err := r.repo.CreateTag(name, h, opts); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+func TestVersionTags_template(t *testing.T) {
+	r := newTestRepo(t)
+	r.tag(r.commit("master"), "svc-api@v1.2.3", "svc-api@v1.3.0-rc.1", "svc-web@v2.0.0", "svc-api-2@v0.1.0", "release-1.2.3", "v9.9.9", "svc-api@vnext")
+	tests := []struct {
+		name      string
+		template  string
+		component string
+		want      []string
+	}{
+		{
+			name:     "any component",
+			template: "{{component}}@v{{version}}",
+			want:     []string{"0.1.0", "1.2.3", "1.3.0-rc.1", "2.0.0"},
+		},
+		{
+			name:      "component",
+			template:  "{{component}}@v{{version}}",
+			component: "svc-api",
+			want:      []string{"1.2.3", "1.3.0-rc.1"},
+		},
+		{
+			name:      "component containing another",
+			template:  "{{component}}@v{{version}}",
+			component: "svc-api-2",
+			want:      []string{"0.1.0"},
+		},
+		{
+			name:      "component without tags",
+			template:  "{{component}}@v{{version}}",
+			component: "svc-db",
+		},
+		{
+			name:     "prefix",
+			template: "release-{{version}}",
+			want:     []string{"1.2.3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTagTemplate(tt.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, v := range VersionTags(r.repo, Options{Scheme: scheme.SemVer{Sufsep: "-"}, Template: tmpl, Component: tt.component}) {
+				got = append(got, v.String())
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("VersionTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPseudoVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		major  uint
+		filter func(scheme.Version) bool
+		// setup builds the repository, returning HEAD
+		setup func(r *testRepo) plumbing.Hash
+		// want is the pseudo-version, without the timestamp and revision
+		want string
+	}{
+		{
+			name: "no tags",
+			setup: func(r *testRepo) plumbing.Hash {
+				return r.commit("master", r.commit("master"))
+			},
+			want: "v0.0.0-",
+		},
+		{
+			name:  "no tags, major version",
+			major: 2,
+			setup: func(r *testRepo) plumbing.Hash {
+				return r.commit("master")
+			},
+			want: "v2.0.0-",
+		},
+		{
+			name: "lightweight tag on an ancestor",
+			setup: func(r *testRepo) plumbing.Hash {
+				c := r.commit("master")
+				r.tag(c, "v1.2.3")
+				return r.commit("master", c)
+			},
+			want: "v1.2.4-0.",
+		},
+		{
+			name: "annotated tags are peeled",
+			setup: func(r *testRepo) plumbing.Hash {
+				c := r.commit("master")
+				r.tag(c, "v1.2.3")
+				c = r.commit("master", c)
+				r.annotatedTag(c, "v1.3.0")
+				return r.commit("master", c)
+			},
+			want: "v1.3.1-0.",
+		},
+		{
+			name: "pre-release tag",
+			setup: func(r *testRepo) plumbing.Hash {
+				c := r.commit("master")
+				r.annotatedTag(c, "v1.3.0-rc.1")
+				return r.commit("master", c)
+			},
+			want: "v1.3.0-rc.1.0.",
+		},
+		{
+			name: "tags not on HEAD's history are ignored",
+			setup: func(r *testRepo) plumbing.Hash {
+				base := r.commit("master")
+				r.tag(base, "v1.0.0")
+				r.tag(r.commit("other", base), "v9.0.0")
+				r.annotatedTag(r.commit("other2", base), "v8.0.0")
+				return r.commit("master", base)
+			},
+			want: "v1.0.1-0.",
+		},
+		{
+			name: "only vX.Y.Z tags count",
+			setup: func(r *testRepo) plumbing.Hash {
+				c := r.commit("master")
+				r.tag(c, "v1.0.0", "2.0.0", "v3.0", "release-v4.0.0", "V5.0.0", "v06.0.0")
+				return r.commit("master", c)
+			},
+			want: "v1.0.1-0.",
+		},
+		{
+			name: "pseudo-version tags are ignored",
+			setup: func(r *testRepo) plumbing.Hash {
+				c := r.commit("master")
+				r.tag(c, "v1.2.3")
+				c = r.commit("master", c)
+				r.tag(c, "v1.2.4-0.20261017120000-abcdef123456", "v0.0.0-20261017120000-abcdef123456")
+				return r.commit("master", c)
+			},
+			want: "v1.2.4-0.",
+		},
+		{
+			name:   "pseudo-version tags are ignored by filters accepting them",
+			filter: func(v scheme.Version) bool { return true },
+			setup: func(r *testRepo) plumbing.Hash {
+				c := r.commit("master")
+				r.tag(c, "v1.3.0-rc.1.0.20261017120000-abcdef123456")
+				return r.commit("master", c)
+			},
+			want: "v0.0.0-",
+		},
+		{
+			name:   "filter",
+			filter: func(v scheme.Version) bool { return v.(semver.SemVer).Major() < 2 },
+			setup: func(r *testRepo) plumbing.Hash {
+				c := r.commit("master")
+				r.tag(c, "v1.5.0", "v2.0.0")
+				return r.commit("master", c)
+			},
+			want: "v1.5.1-0.",
+		},
+		{
+			name:   "filter rejecting all tags",
+			major:  3,
+			filter: func(v scheme.Version) bool { return false },
+			setup: func(r *testRepo) plumbing.Hash {
+				c := r.commit("master")
+				r.tag(c, "v1.5.0")
+				return r.commit("master", c)
+			},
+			want: "v3.0.0-",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			head := tt.setup(r)
+			r.checkout("master")
+			got, err := PseudoVersion(r.repo, Options{Filter: tt.filter}, tt.major)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tt.want + r.when.Format("20060102150405") + "-" + head.String()[:12]
+			if got.String() != want {
+				t.Errorf("PseudoVersion() = %v, want %v", got, want)
+			}
+		})
+	}
+}
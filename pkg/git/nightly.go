@@ -0,0 +1,67 @@
+package git
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	ggit "github.com/go-git/go-git/v5"
+
+	"github.com/adamhassel/semvergo/pkg/scheme"
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+// NightlyLabel is the pre-release identifier of nightly versions
+const NightlyLabel = "nightly"
+
+// nightlyDate is the layout of the date of nightly versions
+const nightlyDate = "20060102"
+
+// LatestReleaseTag returns the latest version tag without a pre-release, as selected by opts, or the zero version if there is none. It is the base of nightly and branch counter versions. opts.Scheme must be semver, and opts.Filter and opts.Branch are ignored
+func LatestReleaseTag(repo *ggit.Repository, opts Options) (semver.SemVer, error) {
+	s, err := opts.semver()
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	opts.Scheme, opts.Branch = s, false
+	opts.Filter = func(v scheme.Version) bool {
+		return v.(semver.SemVer).Prerelease() == ""
+	}
+	v, err := LatestVersionTag(repo, opts)
+	if err != nil || v == nil {
+		return s.Zero(), err
+	}
+	return v.(semver.SemVer), nil
+}
+
+// Nightly returns base with the nightly pre-release of date, like 1.5.0-nightly.20261017.1. The date is in UTC. The counter is one more than the highest counter of the repository's nightly tags of the same version and date, as selected by opts, so the first nightly of a day is 1. opts.Scheme must be semver. Any build metadata of base is dropped
+func Nightly(repo *ggit.Repository, opts Options, base semver.SemVer, date time.Time) (semver.SemVer, error) {
+	return withCounter(repo, opts, base, NightlyLabel, date.UTC().Format(nightlyDate))
+}
+
+// withCounter returns base with the pre-release of labels followed by a counter, which is one more than the highest counter of the repository's tags of the same version and labels, or 1. Any build metadata of base is dropped
+func withCounter(repo *ggit.Repository, opts Options, base semver.SemVer, labels ...string) (semver.SemVer, error) {
+	s, err := opts.semver()
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	opts.Scheme = s
+	var counter uint64
+	for _, v := range VersionTags(repo, opts) {
+		sv := v.(semver.SemVer)
+		if sv.Version() != base.Version() {
+			continue
+		}
+		ids := strings.Split(sv.Prerelease(), ".")
+		if len(ids) != len(labels)+1 || !slices.Equal(ids[:len(labels)], labels) {
+			continue
+		}
+		if n, err := strconv.ParseUint(ids[len(labels)], 10, 64); err == nil {
+			counter = max(counter, n)
+		}
+	}
+	ids := append(slices.Clone(labels), strconv.FormatUint(counter+1, 10))
+	opts.logger().Info("counter computed", "version", base.Version(), "labels", strings.Join(labels, "."), "counter", counter+1)
+	return base.WithBuild("").WithPrerelease(strings.Join(ids, ".")), nil
+}
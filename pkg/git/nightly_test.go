@@ -0,0 +1,200 @@
+package git
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adamhassel/semvergo/pkg/scheme"
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+func mustParse(t *testing.T, s string) semver.SemVer {
+	t.Helper()
+	v, err := semver.ParseSeparated(s, "", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestNightly(t *testing.T) {
+	date := time.Date(2026, 10, 17, 23, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		tags []string
+		base string
+		date time.Time
+		want string
+	}{
+		{
+			name: "first build",
+			tags: []string{"v1.4.0"},
+			base: "v1.5.0",
+			want: "v1.5.0-nightly.20261017.1",
+		},
+		{
+			name: "next build of the same date and version",
+			tags: []string{"v1.4.0", "v1.5.0-nightly.20261017.1", "v1.5.0-nightly.20261017.2"},
+			base: "v1.5.0",
+			want: "v1.5.0-nightly.20261017.3",
+		},
+		{
+			name: "counters compare as numbers",
+			tags: []string{"v1.5.0-nightly.20261017.9", "v1.5.0-nightly.20261017.10"},
+			base: "v1.5.0",
+			want: "v1.5.0-nightly.20261017.11",
+		},
+		{
+			name: "other dates, versions and labels are ignored",
+			tags: []string{"v1.5.0-nightly.20261016.4", "v1.4.0-nightly.20261017.5", "v1.5.0-beta.20261017.6", "v1.5.0-nightly.20261017", "v1.5.0-nightly.20261017.1.1"},
+			base: "v1.5.0",
+			want: "v1.5.0-nightly.20261017.1",
+		},
+		{
+			name: "build metadata is dropped",
+			tags: []string{"v1.5.0-nightly.20261017.1+b.1"},
+			base: "v1.5.0+b.2",
+			want: "v1.5.0-nightly.20261017.2",
+		},
+		{
+			name: "the date is in UTC",
+			base: "v1.5.0",
+			date: time.Date(2026, 10, 18, 1, 30, 0, 0, time.FixedZone("CEST", 7200)),
+			want: "v1.5.0-nightly.20261017.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			r.tag(r.commit("master"), tt.tags...)
+			d := tt.date
+			if d.IsZero() {
+				d = date
+			}
+			got, err := Nightly(r.repo, Options{Scheme: scheme.SemVer{Sufsep: "-"}}, mustParse(t, tt.base), d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Nightly() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNightly_scheme(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("master")
+	if _, err := Nightly(r.repo, Options{Scheme: &scheme.Numeric{}}, mustParse(t, "1.0.0"), time.Now()); !errors.Is(err, ErrSemVerScheme) {
+		t.Errorf("Nightly() error = %v, want %v", err, ErrSemVerScheme)
+	}
+}
+
+func TestLatestReleaseTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want string
+	}{
+		{
+			name: "pre-releases are skipped",
+			tags: []string{"v1.4.0", "v1.5.0-rc.1", "v1.5.0-nightly.20261017.1", "v1.4.1+b.1"},
+			want: "v1.4.1+b.1",
+		},
+		{
+			name: "only pre-releases",
+			tags: []string{"v1.5.0-rc.1"},
+			want: "0.0.0",
+		},
+		{
+			name: "no tags",
+			want: "0.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			r.tag(r.commit("master"), tt.tags...)
+			got, err := LatestReleaseTag(r.repo, Options{Scheme: scheme.SemVer{Sufsep: "-"}, Branch: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("LatestReleaseTag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchCounter(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch string
+		tags   []string
+		base   string
+		want   string
+	}{
+		{
+			name:   "first build",
+			branch: "feature/login",
+			tags:   []string{"v1.4.0"},
+			base:   "v1.4.1",
+			want:   "v1.4.1-feature-login.1",
+		},
+		{
+			name:   "next build",
+			branch: "feature/login",
+			tags:   []string{"v1.4.0", "v1.4.1-feature-login.1", "v1.4.1-feature-login.2"},
+			base:   "v1.4.1",
+			want:   "v1.4.1-feature-login.3",
+		},
+		{
+			name:   "other branches and versions are ignored",
+			branch: "feature/login",
+			tags:   []string{"v1.4.1-feature-logout.5", "v1.4.1-feature.6", "v1.4.0-feature-login.7", "v1.4.1-nightly.20261017.8"},
+			base:   "v1.4.1",
+			want:   "v1.4.1-feature-login.1",
+		},
+		{
+			name:   "build metadata is dropped",
+			branch: "main",
+			tags:   []string{"v2.0.0-main.1+b.1"},
+			base:   "v2.0.0+b.2",
+			want:   "v2.0.0-main.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRepo(t)
+			r.tag(r.commit(tt.branch), tt.tags...)
+			got, err := BranchCounter(r.repo, Options{Scheme: scheme.SemVer{Sufsep: "-"}}, mustParse(t, tt.base))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("BranchCounter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchLabel(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{branch: "main", want: "main"},
+		{branch: "feature/login", want: "feature-login"},
+		{branch: "fix/JIRA-12_løgin", want: "fix-JIRA-12-l-gin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			r := newTestRepo(t)
+			r.commit(tt.branch)
+			if got := BranchLabel(r.repo); got != tt.want {
+				t.Errorf("BranchLabel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
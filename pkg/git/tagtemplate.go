@@ -0,0 +1,84 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidTagTemplate = errors.New("invalid tag template")
+
+// placeholderRE matches the placeholders of tag templates
+var placeholderRE = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// versionExpr is the semver grammar of the version in tag names, with a '-' before any pre-release
+const versionExpr = `(?:0|[1-9][0-9]*)\.(?:0|[1-9][0-9]*)\.(?:0|[1-9][0-9]*)` +
+	`(?:-(?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*))*)?` +
+	`(?:\+[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*)?`
+
+// TagTemplate is the form of version tag names, with the placeholders {{version}} and, for repositories with several components, {{component}}, like "release-{{version}}" or "{{component}}@v{{version}}". It is used both to parse existing tags and to name new ones
+type TagTemplate struct {
+	text string
+	re   *regexp.Regexp
+}
+
+// ParseTagTemplate compiles a tag template. It must have exactly one {{version}}, and at most one {{component}}. The version must be a semantic version, and the component is the shortest match, so with "{{component}}-{{version}}", svc-api-2-1.2.3 is version 1.2.3 of svc-api-2
+func ParseTagTemplate(text string) (*TagTemplate, error) {
+	expr := strings.Builder{}
+	expr.WriteByte('^')
+	counts := map[string]int{}
+	last := 0
+	for _, m := range placeholderRE.FindAllStringSubmatchIndex(text, -1) {
+		expr.WriteString(regexp.QuoteMeta(text[last:m[0]]))
+		name := text[m[2]:m[3]]
+		switch name {
+		case "version":
+			expr.WriteString(`(?P<version>` + versionExpr + `)`)
+		case "component":
+			expr.WriteString(`(?P<component>.+?)`)
+		default:
+			return nil, fmt.Errorf("%w: unknown placeholder {{%s}} in %q", ErrInvalidTagTemplate, name, text)
+		}
+		counts[name]++
+		last = m[1]
+	}
+	expr.WriteString(regexp.QuoteMeta(text[last:]))
+	expr.WriteByte('$')
+	if counts["version"] != 1 || counts["component"] > 1 {
+		return nil, fmt.Errorf("%w: %q must have one {{version}} and at most one {{component}}", ErrInvalidTagTemplate, text)
+	}
+	return &TagTemplate{text: text, re: regexp.MustCompile(expr.String())}, nil
+}
+
+// String returns the template text
+func (t *TagTemplate) String() string {
+	return t.text
+}
+
+// HasComponent reports whether t has a {{component}} placeholder
+func (t *TagTemplate) HasComponent() bool {
+	return t.re.SubexpIndex("component") >= 0
+}
+
+// Match extracts the component and version from tag. The component is empty if t has none. ok is false if tag doesn't match t
+func (t *TagTemplate) Match(tag string) (component, version string, ok bool) {
+	m := t.re.FindStringSubmatch(tag)
+	if m == nil {
+		return "", "", false
+	}
+	if i := t.re.SubexpIndex("component"); i >= 0 {
+		component = m[i]
+	}
+	return component, m[t.re.SubexpIndex("version")], true
+}
+
+// Tag returns the tag name of version of component
+func (t *TagTemplate) Tag(component, version string) string {
+	return placeholderRE.ReplaceAllStringFunc(t.text, func(p string) string {
+		if placeholderRE.FindStringSubmatch(p)[1] == "component" {
+			return component
+		}
+		return version
+	})
+}
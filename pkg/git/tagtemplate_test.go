@@ -0,0 +1,189 @@
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseTagTemplate(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		wantComponent bool
+		wantErr       error
+	}{
+		{
+			name:          "component",
+			text:          "{{component}}@v{{version}}",
+			wantComponent: true,
+		},
+		{
+			name: "prefix",
+			text: "release-{{version}}",
+		},
+		{
+			name:          "spaces in placeholders",
+			text:          "{{ component }}/{{ version }}",
+			wantComponent: true,
+		},
+		{
+			name:    "missing version",
+			text:    "{{component}}",
+			wantErr: ErrInvalidTagTemplate,
+		},
+		{
+			name:    "duplicate version",
+			text:    "{{version}}-{{version}}",
+			wantErr: ErrInvalidTagTemplate,
+		},
+		{
+			name:    "duplicate component",
+			text:    "{{component}}-{{component}}-{{version}}",
+			wantErr: ErrInvalidTagTemplate,
+		},
+		{
+			name:    "unknown placeholder",
+			text:    "{{name}}-{{version}}",
+			wantErr: ErrInvalidTagTemplate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTagTemplate(tt.text)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseTagTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.text {
+				t.Errorf("String() = %q, want %q", got.String(), tt.text)
+			}
+			if got.HasComponent() != tt.wantComponent {
+				t.Errorf("HasComponent() = %v, want %v", got.HasComponent(), tt.wantComponent)
+			}
+		})
+	}
+}
+
+func TestTagTemplate_Match(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		tag           string
+		wantComponent string
+		wantVersion   string
+		wantOK        bool
+	}{
+		{
+			name:          "component",
+			text:          "{{component}}@v{{version}}",
+			tag:           "svc-api@v1.2.3",
+			wantComponent: "svc-api",
+			wantVersion:   "1.2.3",
+			wantOK:        true,
+		},
+		{
+			name:          "component with pre-release and build",
+			text:          "{{component}}@v{{version}}",
+			tag:           "svc-api@v1.2.3-rc.1+b.5",
+			wantComponent: "svc-api",
+			wantVersion:   "1.2.3-rc.1+b.5",
+			wantOK:        true,
+		},
+		{
+			name:        "prefix",
+			text:        "release-{{version}}",
+			tag:         "release-1.2.3",
+			wantVersion: "1.2.3",
+			wantOK:      true,
+		},
+		{
+			name:   "other prefix",
+			text:   "release-{{version}}",
+			tag:    "v1.2.3",
+			wantOK: false,
+		},
+		{
+			name:   "literal is not a pattern",
+			text:   "release.{{version}}",
+			tag:    "release-1.2.3",
+			wantOK: false,
+		},
+		{
+			name:          "component containing the separator",
+			text:          "{{component}}-{{version}}",
+			tag:           "svc-api-2-1.2.3",
+			wantComponent: "svc-api-2",
+			wantVersion:   "1.2.3",
+			wantOK:        true,
+		},
+		{
+			name:          "component containing the separator, with a pre-release",
+			text:          "{{component}}-{{version}}",
+			tag:           "svc-api-1.2.3-rc.1",
+			wantComponent: "svc-api",
+			wantVersion:   "1.2.3-rc.1",
+			wantOK:        true,
+		},
+		{
+			name:   "not a semantic version",
+			text:   "{{component}}-{{version}}",
+			tag:    "svc-api-1.2",
+			wantOK: false,
+		},
+		{
+			name:   "leading zero",
+			text:   "release-{{version}}",
+			tag:    "release-1.02.3",
+			wantOK: false,
+		},
+		{
+			name:   "empty component",
+			text:   "{{component}}@v{{version}}",
+			tag:    "@v1.2.3",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTagTemplate(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			component, version, ok := tmpl.Match(tt.tag)
+			if ok != tt.wantOK || component != tt.wantComponent || version != tt.wantVersion {
+				t.Fatalf("Match() = %q, %q, %v, want %q, %q, %v", component, version, ok, tt.wantComponent, tt.wantVersion, tt.wantOK)
+			}
+			if ok {
+				if got := tmpl.Tag(component, version); got != tt.tag {
+					t.Errorf("Tag(Match()) = %q, want %q", got, tt.tag)
+				}
+			}
+		})
+	}
+}
+
+func TestTagTemplate_Tag(t *testing.T) {
+	tests := []struct {
+		text      string
+		component string
+		version   string
+		want      string
+	}{
+		{text: "{{component}}@v{{version}}", component: "svc-api", version: "1.2.4", want: "svc-api@v1.2.4"},
+		{text: "release-{{ version }}", version: "1.2.4-rc.1", want: "release-1.2.4-rc.1"},
+		{text: "{{component}}/{{version}}", component: "web", version: "2.0.0", want: "web/2.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			tmpl, err := ParseTagTemplate(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tmpl.Tag(tt.component, tt.version); got != tt.want {
+				t.Errorf("Tag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
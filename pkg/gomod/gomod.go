@@ -0,0 +1,336 @@
+package gomod
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+	"github.com/adamhassel/semvergo/pkg/updater"
+)
+
+// Incompatible is the build metadata marking a v2+ version of a module without a major version suffix
+const Incompatible = "incompatible"
+
+var ErrNoModule = errors.New("no module directive in go.mod")
+var ErrMajorMismatch = errors.New("major version does not match module path")
+
+var (
+	moduleRE = regexp.MustCompile(`(?m)^[ \t]*module[ \t]+("[^"]+"|\x60[^\x60]+\x60|[^\s/]\S*)[ \t]*(?://.*)?$`)
+	majorRE  = regexp.MustCompile(`/v([0-9]+)$`)
+	gopkgRE  = regexp.MustCompile(`^gopkg\.in/.*\.v([0-9]+)(?:-unstable)?$`)
+	pseudoRE = regexp.MustCompile(`^(?:(?:[^+]*\.)?0\.)?[0-9]{14}-[0-9A-Za-z]+$`)
+)
+
+// ModulePath returns the module path declared in the contents of a go.mod file
+func ModulePath(gomod []byte) (string, error) {
+	m := moduleRE.FindSubmatch(gomod)
+	if m == nil {
+		return "", ErrNoModule
+	}
+	path := string(m[1])
+	if path[0] == '"' || path[0] == '`' {
+		return strconv.Unquote(path)
+	}
+	return path, nil
+}
+
+// ReadModulePath returns the module path declared in the go.mod file in dir
+func ReadModulePath(dir string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	return ModulePath(b)
+}
+
+// PathMajor returns the major version given by the suffix of the module path, like 2 for "example.com/foo/v2" or "gopkg.in/yaml.v2". The boolean is false if the path has no major version suffix
+func PathMajor(path string) (uint, bool) {
+	m := gopkgRE.FindStringSubmatch(path)
+	if m == nil {
+		m = majorRE.FindStringSubmatch(path)
+	}
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(m[1], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(n), true
+}
+
+// MajorPath returns path with the major version suffix changed to match major. Major versions 0 and 1 have no suffix. gopkg.in paths are returned unchanged
+func MajorPath(path string, major uint) string {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		return path
+	}
+	path = majorRE.ReplaceAllString(path, "")
+	if major < 2 {
+		return path
+	}
+	return fmt.Sprintf("%s/v%d", path, major)
+}
+
+// IsIncompatible returns true if v is marked '+incompatible'
+func IsIncompatible(v semver.SemVer) bool {
+	return v.Build() == Incompatible
+}
+
+// IsPseudo returns true if v is a Go pseudo-version, like v0.0.0-20261017120000-abcdef123456
+func IsPseudo(v semver.SemVer) bool {
+	pre := v.Prerelease()
+	if !pseudoRE.MatchString(pre) {
+		return false
+	}
+	// the vX.0.0-yyyymmddhhmmss-abcdefabcdef form has no base version
+	return strings.Contains(pre, ".") || (v.Minor() == 0 && v.Patch() == 0)
+}
+
+// Check returns an error if v is not a valid version of the module at path, as declared in a go.mod file. Versions v0 and v1 must not have a major version suffix in the path, and v2 and higher must have a matching suffix. '+incompatible' versions are never valid, as they are only for modules without a go.mod file
+func Check(path string, v semver.SemVer) error {
+	major, ok := PathMajor(path)
+	switch {
+	case IsIncompatible(v):
+		return fmt.Errorf("%w: +incompatible is only valid for a module without a go.mod file, got %s for %s", ErrMajorMismatch, v.String(), path)
+	case strings.HasPrefix(path, "gopkg.in/") && ok && v.Major() == major:
+		return nil
+	case !ok && v.Major() < 2:
+		return nil
+	case ok && v.Major() == major && major >= 2:
+		return nil
+	}
+	return fmt.Errorf("%w: version %s requires module path %s, got %s", ErrMajorMismatch, v.String(), MajorPath(path, v.Major()), path)
+}
+
+// Compatible returns a filter accepting only versions that are valid for the module at path, and are not pseudo-versions
+func Compatible(path string) func(semver.SemVer) bool {
+	return func(v semver.SemVer) bool {
+		return !IsPseudo(v) && Check(path, v) == nil
+	}
+}
+
+// fileEdit is the new content of a file
+type fileEdit struct {
+	path     string
+	old, new []byte
+}
+
+// Rewrite changes the module path in the go.mod file in dir from oldPath to newPath, and rewrites all imports of oldPath and its packages in Go files below dir. Nested modules, vendor and testdata directories are skipped. All files are parsed before any is written, so a file that doesn't parse leaves the module untouched. If dryRun is true, no files are written, and a diff of the changes is written to w instead
+func Rewrite(dir, oldPath, newPath string, dryRun bool, w io.Writer) error {
+	edits, err := rewrites(dir, oldPath, newPath)
+	if err != nil {
+		return err
+	}
+	for _, e := range edits {
+		if dryRun {
+			name, err := filepath.Rel(dir, e.path)
+			if err != nil {
+				return err
+			}
+			if err := updater.Diff(w, filepath.ToSlash(name), e.old, e.new); err != nil {
+				return err
+			}
+			continue
+		}
+		fi, err := os.Stat(e.path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(e.path, e.new, fi.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewrites returns the edits of the go.mod file in dir and the Go files below it changing the module path from oldPath to newPath
+func rewrites(dir, oldPath, newPath string) ([]fileEdit, error) {
+	gomod := filepath.Join(dir, "go.mod")
+	b, err := os.ReadFile(gomod)
+	if err != nil {
+		return nil, err
+	}
+	m := moduleRE.FindSubmatchIndex(b)
+	if m == nil {
+		return nil, ErrNoModule
+	}
+	edits := []fileEdit{{path: gomod, old: b, new: append(b[:m[2]:m[2]], append([]byte(newPath), b[m[3]:]...)...)}}
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == dir {
+				return nil
+			}
+			if name := d.Name(); name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		updated, err := rewriteImports(path, src, oldPath, newPath)
+		if err != nil || updated == nil {
+			return err
+		}
+		edits = append(edits, fileEdit{path: path, old: src, new: updated})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
+
+// rewriteImports returns src, the Go source file at path, with imports of oldPath, or packages below it, changed to newPath. It returns nil if there are none
+func rewriteImports(path string, src []byte, oldPath, newPath string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, src, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	last := 0
+	for _, imp := range f.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		if p != oldPath && !strings.HasPrefix(p, oldPath+"/") {
+			continue
+		}
+		start := fset.Position(imp.Path.Pos()).Offset
+		end := fset.Position(imp.Path.End()).Offset
+		b.Write(src[last:start])
+		b.WriteString(strconv.Quote(newPath + strings.TrimPrefix(p, oldPath)))
+		last = end
+	}
+	if last == 0 {
+		return nil, nil
+	}
+	b.Write(src[last:])
+	return b.Bytes(), nil
+}
+
+// pseudoTimeFormat is the layout of the timestamp in pseudo-versions
+const pseudoTimeFormat = "20060102150405"
+
+// PseudoRevLen is the length of the abbreviated revision in pseudo-versions
+const PseudoRevLen = 12
+
+var ErrNotPseudo = errors.New("not a pseudo-version")
+
+// PseudoVersion returns the pseudo-version the go command computes for revision rev, committed at t. older is the highest version tag reachable from the revision, or nil if there is none, in which case major is the major version of the module.
+//
+// The three forms are vX.0.0-yyyymmddhhmmss-abcdefabcdef when there is no tag, vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef when the tag is the pre-release vX.Y.Z-pre, and vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef when the tag is the release vX.Y.Z. '+incompatible' is kept from the tag.
+func PseudoVersion(major uint, older *semver.SemVer, t time.Time, rev string) semver.SemVer {
+	if len(rev) > PseudoRevLen {
+		rev = rev[:PseudoRevLen]
+	}
+	ts := t.UTC().Format(pseudoTimeFormat)
+	var s string
+	switch {
+	case older == nil:
+		s = fmt.Sprintf("v%d.0.0-%s-%s", major, ts, rev)
+	case older.Prerelease() != "":
+		s = fmt.Sprintf("v%s-%s.0.%s-%s", older.Version(), older.Prerelease(), ts, rev)
+	default:
+		s = fmt.Sprintf("v%d.%d.%d-0.%s-%s", older.Major(), older.Minor(), older.Patch()+1, ts, rev)
+	}
+	if older != nil && IsIncompatible(*older) {
+		s += "+" + Incompatible
+	}
+	v, err := semver.ParseSeparated(s, "", "-")
+	if err != nil {
+		// can't happen, we just built a valid version string
+		panic(err)
+	}
+	return v
+}
+
+// ParsePseudo parses a pseudo-version, returning ErrNotPseudo if s is a valid version, but not a pseudo-version
+func ParsePseudo(s string) (semver.SemVer, error) {
+	v, err := semver.ParseSeparated(s, "", "-")
+	if err != nil {
+		return semver.SemVer{}, err
+	}
+	if !IsPseudo(v) {
+		return semver.SemVer{}, fmt.Errorf("%w: %s", ErrNotPseudo, s)
+	}
+	return v, nil
+}
+
+// pseudoParts splits the pre-release of the pseudo-version v into the base pre-release (empty if none), the timestamp and the revision
+func pseudoParts(v semver.SemVer) (string, string, string, error) {
+	if !IsPseudo(v) {
+		return "", "", "", fmt.Errorf("%w: %s", ErrNotPseudo, v.String())
+	}
+	pre := v.Prerelease()
+	base, tsrev := "", pre
+	if i := strings.LastIndex(pre, "."); i >= 0 {
+		base, tsrev = pre[:i], pre[i+1:]
+	}
+	ts, rev, _ := strings.Cut(tsrev, "-")
+	return base, ts, rev, nil
+}
+
+// PseudoTime returns the commit time encoded in the pseudo-version v
+func PseudoTime(v semver.SemVer) (time.Time, error) {
+	_, ts, _, err := pseudoParts(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(pseudoTimeFormat, ts)
+}
+
+// PseudoRev returns the abbreviated revision encoded in the pseudo-version v
+func PseudoRev(v semver.SemVer) (string, error) {
+	_, _, rev, err := pseudoParts(v)
+	return rev, err
+}
+
+// PseudoBase returns the tagged version the pseudo-version v is based on. The boolean is false if v was not based on a tag
+func PseudoBase(v semver.SemVer) (semver.SemVer, bool, error) {
+	base, _, _, err := pseudoParts(v)
+	if err != nil {
+		return semver.SemVer{}, false, err
+	}
+	var s string
+	switch {
+	case base == "":
+		return semver.SemVer{}, false, nil
+	case base == "0":
+		if v.Patch() == 0 {
+			return semver.SemVer{}, false, fmt.Errorf("%w: %s", ErrNotPseudo, v.String())
+		}
+		s = fmt.Sprintf("v%d.%d.%d", v.Major(), v.Minor(), v.Patch()-1)
+	default:
+		s = fmt.Sprintf("v%s-%s", v.Version(), strings.TrimSuffix(base, ".0"))
+	}
+	if IsIncompatible(v) {
+		s += "+" + Incompatible
+	}
+	older, err := semver.ParseSeparated(s, "", "-")
+	return older, true, err
+}
@@ -0,0 +1,370 @@
+package gomod
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+func mustParse(t *testing.T, s string) semver.SemVer {
+	t.Helper()
+	v, err := semver.ParseSeparated(s, "", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestModulePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		gomod   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "plain",
+			gomod: "module example.com/foo\n\ngo 1.22\n",
+			want:  "example.com/foo",
+		},
+		{
+			name:  "quoted, with comment",
+			gomod: "// a module\nmodule \"example.com/foo/v2\" // v2\n",
+			want:  "example.com/foo/v2",
+		},
+		{
+			name:    "no module",
+			gomod:   "go 1.22\n",
+			wantErr: ErrNoModule,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ModulePath([]byte(tt.gomod))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ModulePath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ModulePath() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathMajor(t *testing.T) {
+	tests := []struct {
+		path   string
+		want   uint
+		wantOk bool
+	}{
+		{path: "example.com/foo"},
+		{path: "example.com/foo/v2", want: 2, wantOk: true},
+		{path: "example.com/foo/v12", want: 12, wantOk: true},
+		{path: "example.com/v2/foo"},
+		{path: "gopkg.in/yaml.v3", want: 3, wantOk: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := PathMajor(tt.path)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("PathMajor() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestMajorPath(t *testing.T) {
+	tests := []struct {
+		path  string
+		major uint
+		want  string
+	}{
+		{path: "example.com/foo", major: 1, want: "example.com/foo"},
+		{path: "example.com/foo", major: 2, want: "example.com/foo/v2"},
+		{path: "example.com/foo/v2", major: 3, want: "example.com/foo/v3"},
+		{path: "example.com/foo/v2", major: 0, want: "example.com/foo"},
+		{path: "gopkg.in/yaml.v2", major: 3, want: "gopkg.in/yaml.v2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := MajorPath(tt.path, tt.major); got != tt.want {
+				t.Errorf("MajorPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		version string
+		wantErr error
+	}{
+		{
+			name:    "v1 without suffix",
+			path:    "example.com/foo",
+			version: "v1.2.3",
+		},
+		{
+			name:    "v0 without suffix",
+			path:    "example.com/foo",
+			version: "v0.2.3",
+		},
+		{
+			name:    "v2 without suffix",
+			path:    "example.com/foo",
+			version: "v2.0.0",
+			wantErr: ErrMajorMismatch,
+		},
+		{
+			name:    "v2 with suffix",
+			path:    "example.com/foo/v2",
+			version: "v2.0.0",
+		},
+		{
+			name:    "v3 with v2 suffix",
+			path:    "example.com/foo/v2",
+			version: "v3.0.0",
+			wantErr: ErrMajorMismatch,
+		},
+		{
+			name:    "v1 with v2 suffix",
+			path:    "example.com/foo/v2",
+			version: "v1.9.0",
+			wantErr: ErrMajorMismatch,
+		},
+		{
+			name:    "incompatible without suffix",
+			path:    "example.com/foo",
+			version: "v3.1.0+incompatible",
+			wantErr: ErrMajorMismatch,
+		},
+		{
+			name:    "incompatible with suffix",
+			path:    "example.com/foo/v3",
+			version: "v3.1.0+incompatible",
+			wantErr: ErrMajorMismatch,
+		},
+		{
+			name:    "incompatible v1",
+			path:    "example.com/foo",
+			version: "v1.1.0+incompatible",
+			wantErr: ErrMajorMismatch,
+		},
+		{
+			name:    "gopkg.in",
+			path:    "gopkg.in/yaml.v3",
+			version: "v3.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Check(tt.path, mustParse(t, tt.version)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsPseudo(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "v0.0.0-20261017120000-abcdef123456", want: true},
+		{version: "v1.2.4-0.20261017120000-abcdef123456", want: true},
+		{version: "v1.2.3-pre.0.20261017120000-abcdef123456", want: true},
+		{version: "v1.2.3-pre.0.20261017120000-abcdef123456+incompatible", want: true},
+		{version: "v1.2.3-20261017120000-abcdef123456", want: false},
+		{version: "v1.2.3"},
+		{version: "v1.2.3-rc.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := IsPseudo(mustParse(t, tt.version)); got != tt.want {
+				t.Errorf("IsPseudo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":             "module example.com/foo\n\ngo 1.22\n",
+		"main.go":            "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/foo/bar\"\n\tbaz \"example.com/foobar\"\n)\n",
+		"bar/bar.go":         "package bar\n\nimport _ \"example.com/foo\"\n",
+		"vendor/x/x.go":      "package x\n\nimport _ \"example.com/foo\"\n",
+		"nested/go.mod":      "module example.com/nested\n",
+		"nested/nested.go":   "package nested\n\nimport _ \"example.com/foo\"\n",
+		"testdata/broken.go": "this is not go",
+	}
+	writeFiles(t, dir, files)
+	if err := Rewrite(dir, "example.com/foo", "example.com/foo/v2", false, nil); err != nil {
+		t.Fatal(err)
+	}
+	checkFiles(t, dir, map[string]string{
+		"go.mod":           "module example.com/foo/v2\n\ngo 1.22\n",
+		"main.go":          "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/foo/v2/bar\"\n\tbaz \"example.com/foobar\"\n)\n",
+		"bar/bar.go":       "package bar\n\nimport _ \"example.com/foo/v2\"\n",
+		"vendor/x/x.go":    files["vendor/x/x.go"],
+		"nested/nested.go": files["nested/nested.go"],
+	})
+}
+
+func TestRewrite_parseError(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":      "module example.com/foo\n",
+		"a/a.go":      "package a\n\nimport _ \"example.com/foo\"\n",
+		"z/broken.go": "this is not go",
+	}
+	writeFiles(t, dir, files)
+	if err := Rewrite(dir, "example.com/foo", "example.com/foo/v2", false, nil); err == nil {
+		t.Fatal("Rewrite() of a module with a broken file didn't fail")
+	}
+	checkFiles(t, dir, files)
+}
+
+func TestRewrite_dryRun(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/foo\n",
+		"a/a.go": "package a\n\nimport _ \"example.com/foo\"\n",
+	}
+	writeFiles(t, dir, files)
+	var b bytes.Buffer
+	if err := Rewrite(dir, "example.com/foo", "example.com/foo/v2", true, &b); err != nil {
+		t.Fatal(err)
+	}
+	checkFiles(t, dir, files)
+	want := "--- a/go.mod\n+++ b/go.mod\n@@ -1 +1 @@\n-module example.com/foo\n+module example.com/foo/v2\n" +
+		"--- a/a/a.go\n+++ b/a/a.go\n@@ -3 +3 @@\n-import _ \"example.com/foo\"\n+import _ \"example.com/foo/v2\"\n"
+	if b.String() != want {
+		t.Errorf("Rewrite() diff = %q, want %q", b.String(), want)
+	}
+}
+
+// writeFiles writes files, by their path relative to dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// checkFiles checks the content of files, by their path relative to dir
+func checkFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != content {
+			t.Errorf("Rewrite() %s = %q, want %q", name, got, content)
+		}
+	}
+}
+
+func TestPseudoVersion(t *testing.T) {
+	ts := time.Date(2026, 10, 17, 14, 0, 0, 0, time.FixedZone("CEST", 7200))
+	rev := "abcdef1234567890abcdef1234567890abcdef12"
+	tests := []struct {
+		name  string
+		major uint
+		older string
+		want  string
+	}{
+		{
+			name: "no tag",
+			want: "v0.0.0-20261017120000-abcdef123456",
+		},
+		{
+			name:  "no tag, v2 module",
+			major: 2,
+			want:  "v2.0.0-20261017120000-abcdef123456",
+		},
+		{
+			name:  "release tag",
+			older: "v1.2.3",
+			want:  "v1.2.4-0.20261017120000-abcdef123456",
+		},
+		{
+			name:  "pre-release tag",
+			older: "v1.2.3-pre",
+			want:  "v1.2.3-pre.0.20261017120000-abcdef123456",
+		},
+		{
+			name:  "incompatible tag",
+			older: "v3.0.0+incompatible",
+			want:  "v3.0.1-0.20261017120000-abcdef123456+incompatible",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var older *semver.SemVer
+			if tt.older != "" {
+				o := mustParse(t, tt.older)
+				older = &o
+			}
+			got := PseudoVersion(tt.major, older, ts, rev)
+			if got.String() != tt.want {
+				t.Errorf("PseudoVersion() = %v, want %v", got.String(), tt.want)
+			}
+
+			parsed, err := ParsePseudo(tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotTime, _ := PseudoTime(parsed); !gotTime.Equal(ts) {
+				t.Errorf("PseudoTime() = %v, want %v", gotTime, ts)
+			}
+			if gotRev, _ := PseudoRev(parsed); gotRev != rev[:PseudoRevLen] {
+				t.Errorf("PseudoRev() = %v, want %v", gotRev, rev[:PseudoRevLen])
+			}
+			base, ok, err := PseudoBase(parsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != (tt.older != "") || (ok && base.String() != tt.older) {
+				t.Errorf("PseudoBase() = %v, %v, want %v", base.String(), ok, tt.older)
+			}
+		})
+	}
+	if _, err := ParsePseudo("v1.2.3"); !errors.Is(err, ErrNotPseudo) {
+		t.Errorf("ParsePseudo() error = %v, wantErr %v", err, ErrNotPseudo)
+	}
+}
+
+func TestPseudoVersionOrder(t *testing.T) {
+	// ascending order, as sorted by the go command
+	versions := []string{
+		"v0.0.0-20261017120000-abcdef123456",
+		"v1.2.3",
+		"v1.2.4-0.20261017120000-abcdef123456",
+		"v1.2.4-0.20261018120000-000000000000",
+		"v1.2.4-pre",
+		"v1.2.4-pre.0.20261017120000-abcdef123456",
+		"v1.2.4",
+	}
+	for i := 1; i < len(versions); i++ {
+		a, b := mustParse(t, versions[i-1]), mustParse(t, versions[i])
+		if semver.Compare(a, b) != -1 {
+			t.Errorf("Compare(%s, %s) != -1", versions[i-1], versions[i])
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package maven
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+var ErrNotSemVer = errors.New("Maven version has no semver equivalent")
+
+// Snapshot is the qualifier of Maven development versions
+const Snapshot = "SNAPSHOT"
+
+// qualifiers are the known qualifiers in ascending order. The empty qualifier is a release
+var qualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// aliases maps alternative spellings of qualifiers to the known qualifiers
+var aliases = map[string]string{"ga": "", "final": "", "release": "", "cr": "rc"}
+
+// kind is the kind of an item of a Maven version
+type kind int
+
+const (
+	numberItem kind = iota
+	stringItem
+	listItem
+)
+
+// item is a number, a qualifier or a list of items, like Maven's ComparableVersion items. A '-' starts a new list, as does a change between digits and letters
+type item struct {
+	kind kind
+	// value is the digits of a number, without leading zeros, or a lower case qualifier
+	value string
+	items []*item
+}
+
+// Version is a Maven artifact version. Any string is a version
+type Version struct {
+	value string
+	items *item
+}
+
+// Parse parses a Maven version, exactly as Maven 3's ComparableVersion does
+func Parse(s string) Version {
+	v := strings.ToLower(s)
+	root := &item{kind: listItem}
+	list := root
+	stack := []*item{root}
+	sub := func() {
+		l := &item{kind: listItem}
+		list.items = append(list.items, l)
+		list = l
+		stack = append(stack, l)
+	}
+	digits, start := false, 0
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		switch {
+		case c == '.' || c == '-':
+			if i == start {
+				list.items = append(list.items, &item{kind: numberItem})
+			} else {
+				list.items = append(list.items, parseItem(digits, v[start:i]))
+			}
+			start = i + 1
+			if c == '-' {
+				sub()
+			}
+		case isDigit(c):
+			if !digits && i > start {
+				// a qualifier followed by a number, like "a1", where a, b and m are short for alpha, beta and milestone
+				list.items = append(list.items, qualifier(v[start:i], true))
+				start = i
+				sub()
+			}
+			digits = true
+		default:
+			if digits && i > start {
+				list.items = append(list.items, parseItem(true, v[start:i]))
+				start = i
+				sub()
+			}
+			digits = false
+		}
+	}
+	if len(v) > start {
+		list.items = append(list.items, parseItem(digits, v[start:]))
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		stack[i].normalize()
+	}
+	return Version{value: s, items: root}
+}
+
+// parseItem returns the number or qualifier s
+func parseItem(digits bool, s string) *item {
+	if digits {
+		return &item{kind: numberItem, value: strings.TrimLeft(s, "0")}
+	}
+	return qualifier(s, false)
+}
+
+// qualifier returns the qualifier s. If followed by a digit, a, b and m are short for alpha, beta and milestone
+func qualifier(s string, followedByDigit bool) *item {
+	if followedByDigit && len(s) == 1 {
+		switch s {
+		case "a":
+			s = "alpha"
+		case "b":
+			s = "beta"
+		case "m":
+			s = "milestone"
+		}
+	}
+	if alias, ok := aliases[s]; ok {
+		s = alias
+	}
+	return &item{kind: stringItem, value: s}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isNull reports whether it is equal to nothing: the number 0, a release qualifier or an empty list
+func (it *item) isNull() bool {
+	switch it.kind {
+	case listItem:
+		return len(it.items) == 0
+	default:
+		return it.value == ""
+	}
+}
+
+// normalize removes trailing null items from a list, skipping over sub-lists
+func (it *item) normalize() {
+	for i := len(it.items) - 1; i >= 0; i-- {
+		if it.items[i].isNull() {
+			it.items = slices.Delete(it.items, i, i+1)
+		} else if it.items[i].kind != listItem {
+			break
+		}
+	}
+}
+
+// rank returns the comparable form of a qualifier. Unknown qualifiers sort after known ones, lexically
+func rank(q string) string {
+	if i := slices.Index(qualifiers, q); i >= 0 {
+		return string(rune('0' + i))
+	}
+	return fmt.Sprintf("%d-%s", len(qualifiers), q)
+}
+
+// compare compares a to b, where nil is nothing
+func compare(a, b *item) int {
+	if a == nil {
+		if b == nil {
+			return 0
+		}
+		return -compare(b, nil)
+	}
+	switch a.kind {
+	case numberItem:
+		switch {
+		case b == nil:
+			return cmp.Compare(len(a.value), 0)
+		case b.kind == numberItem:
+			// numbers of any size: longer is larger, then lexically
+			if c := cmp.Compare(len(a.value), len(b.value)); c != 0 {
+				return c
+			}
+			return strings.Compare(a.value, b.value)
+		}
+		// 1.1 > 1-sp and 1.1 > 1-1
+		return 1
+	case stringItem:
+		switch {
+		case b == nil:
+			// 1-rc < 1, 1-sp > 1
+			return strings.Compare(rank(a.value), rank(""))
+		case b.kind == stringItem:
+			return strings.Compare(rank(a.value), rank(b.value))
+		}
+		return -1
+	}
+	switch {
+	case b == nil:
+		if len(a.items) == 0 {
+			return 0
+		}
+		return compare(a.items[0], nil)
+	case b.kind == numberItem:
+		return -1
+	case b.kind == stringItem:
+		return 1
+	}
+	for i := 0; i < max(len(a.items), len(b.items)); i++ {
+		var l, r *item
+		if i < len(a.items) {
+			l = a.items[i]
+		}
+		if i < len(b.items) {
+			r = b.items[i]
+		}
+		if c := compare(l, r); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// String returns the version as it was parsed
+func (v Version) String() string {
+	return v.value
+}
+
+// Canonical returns the canonical form of v. Versions are equal if and only if their canonical forms are
+func (v Version) Canonical() string {
+	return v.root().String()
+}
+
+func (it *item) String() string {
+	if it.kind != listItem {
+		return it.value
+	}
+	b := strings.Builder{}
+	for _, i := range it.items {
+		if b.Len() > 0 {
+			if i.kind == listItem {
+				b.WriteByte('-')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		if i.kind == numberItem && i.value == "" {
+			b.WriteByte('0')
+			continue
+		}
+		b.WriteString(i.String())
+	}
+	return b.String()
+}
+
+// Compare returns -1, 0 or 1 as a sorts before, equal to or after b, as Maven's ComparableVersion
+func Compare(a, b Version) int {
+	return compare(a.root(), b.root())
+}
+
+// root returns the parsed items of v. The zero Version is the empty version
+func (v Version) root() *item {
+	if v.items == nil {
+		return &item{kind: listItem}
+	}
+	return v.items
+}
+
+// IsSnapshot reports whether v is a development version, ending with "-SNAPSHOT"
+func (v Version) IsSnapshot() bool {
+	return strings.HasSuffix(strings.ToUpper(v.value), "-"+Snapshot)
+}
+
+// FromSemVer returns the Maven version of sv. The pre-release identifiers are separated by '-', so 1.2.3-rc.1 becomes 1.2.3-rc-1, and it sorts before the release if it starts with alpha, beta, milestone, rc or snapshot. Build metadata and any prefix are dropped
+func FromSemVer(sv semver.SemVer) Version {
+	s := sv.Version()
+	if pre := sv.Prerelease(); pre != "" {
+		s += "-" + strings.ReplaceAll(pre, ".", "-")
+	}
+	return Parse(s)
+}
+
+// SnapshotFromSemVer returns the Maven version of sv as a release or a development version: a pre-release like 1.2.4-dev.3 becomes 1.2.4-SNAPSHOT, a release is kept. Build metadata and any prefix are dropped
+func SnapshotFromSemVer(sv semver.SemVer) Version {
+	s := sv.Version()
+	if sv.Prerelease() != "" {
+		s += "-" + Snapshot
+	}
+	return Parse(s)
+}
+
+// ToSemVer converts v to a semantic version. Up to three leading numbers become the major, minor and patch versions, and the rest becomes the pre-release, like 1.2-beta-2 becoming 1.2.0-beta.2. Versions with more than three numbers, or that sort after the release like 1.2.3-sp1 or 1.2.3-1, can't be converted
+func ToSemVer(v Version) (semver.SemVer, error) {
+	items := v.root().items
+	var release [3]string
+	n := 0
+	for ; n < len(items) && items[n].kind == numberItem; n++ {
+		if n == len(release) {
+			return semver.SemVer{}, fmt.Errorf("%w: %s has more than three numbers", ErrNotSemVer, v)
+		}
+		release[n] = items[n].String()
+	}
+	var ids []string
+	var flatten func(items []*item)
+	flatten = func(items []*item) {
+		for _, it := range items {
+			if it.kind == listItem {
+				flatten(it.items)
+				continue
+			}
+			id := it.value
+			if it.kind == numberItem && id == "" {
+				id = "0"
+			}
+			ids = append(ids, id)
+		}
+	}
+	flatten(items[n:])
+	if len(ids) > 0 && strings.Compare(rank(ids[0]), rank("")) >= 0 {
+		return semver.SemVer{}, fmt.Errorf("%w: %s sorts after its release", ErrNotSemVer, v)
+	}
+	for i, r := range release {
+		if r == "" {
+			release[i] = "0"
+		}
+	}
+	s := strings.Join(release[:], ".")
+	if len(ids) > 0 {
+		if ids[0] == "snapshot" {
+			ids[0] = Snapshot
+		}
+		s += "-" + strings.Join(ids, ".")
+	}
+	sv, err := semver.ParseSeparated(s, "", "-")
+	if err != nil {
+		return semver.SemVer{}, fmt.Errorf("%w: %s: %v", ErrNotSemVer, v, err)
+	}
+	return sv, nil
+}
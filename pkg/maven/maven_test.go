@@ -0,0 +1,191 @@
+package maven
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+// checkOrder checks that vs are in strictly ascending order
+func checkOrder(t *testing.T, vs []string) {
+	t.Helper()
+	for i := range vs {
+		for j := range vs {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := Compare(Parse(vs[i]), Parse(vs[j])); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", vs[i], vs[j], got, want)
+			}
+		}
+	}
+}
+
+// The orders and equalities of Maven's ComparableVersionTest
+func TestCompare_qualifiers(t *testing.T) {
+	checkOrder(t, []string{
+		"1-alpha2snapshot", "1-alpha2", "1-alpha-123", "1-beta-2", "1-beta123", "1-m2", "1-m11", "1-rc", "1-cr2",
+		"1-rc123", "1-SNAPSHOT", "1", "1-sp", "1-sp2", "1-sp123", "1-abc", "1-def", "1-pom-1", "1-1-snapshot",
+		"1-1", "1-2", "1-123",
+	})
+}
+
+func TestCompare_numbers(t *testing.T) {
+	checkOrder(t, []string{
+		"2.0", "2-1", "2.0.a", "2.0.0.a", "2.0.2", "2.0.123", "2.1.0", "2.1-a", "2.1b", "2.1-c", "2.1-1", "2.1.0.1",
+		"2.2", "2.123", "11.a2", "11.a11", "11.b2", "11.b11", "11.m2", "11.m11", "11", "11.a", "11b", "11c", "11m",
+	})
+}
+
+func TestCompare_equal(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"1", "1.0.0"},
+		{"1.0", "1.0.0"},
+		{"1", "1-0"},
+		{"1", "1.0-0"},
+		{"1a", "1-a"},
+		{"1a", "1.0.0-a"},
+		{"1.0a", "1-a"},
+		{"1x", "1.0-x"},
+		{"1ga", "1"},
+		{"1release", "1"},
+		{"1final", "1"},
+		{"1.2.3.Final", "1.2.3"},
+		{"1cr", "1rc"},
+		{"1a1", "1-alpha-1"},
+		{"1b2", "1-beta-2"},
+		{"1m3", "1-milestone-3"},
+		{"1X", "1x"},
+		{"1A", "1a"},
+		{"1-SNAPSHOT", "1-snapshot"},
+		{"01.002", "1.2"},
+		{"1.123456789012345678901234567890", "1.0123456789012345678901234567890"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"="+tt.b, func(t *testing.T) {
+			a, b := Parse(tt.a), Parse(tt.b)
+			if got := Compare(a, b); got != 0 {
+				t.Errorf("Compare() = %d, want 0", got)
+			}
+			if a.Canonical() != b.Canonical() {
+				t.Errorf("Canonical() = %q and %q", a.Canonical(), b.Canonical())
+			}
+		})
+	}
+}
+
+func TestCompare_large(t *testing.T) {
+	checkOrder(t, []string{"1.99999999", "1.100000000", "1.999999999999999999", "1.1000000000000000000", "1.99999999999999999999999"})
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "1.0a1", want: "1-alpha-1"},
+		{s: "1.2-beta-2", want: "1.2-beta-2"},
+		{s: "1.2.3.Final", want: "1.2.3"},
+		{s: "1.0.0-SNAPSHOT", want: "1-snapshot"},
+		{s: "1.0.x1", want: "1.0.x-1"},
+		{s: "", want: ""},
+		{s: "1.0-", want: "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			v := Parse(tt.s)
+			if got := v.Canonical(); got != tt.want {
+				t.Errorf("Canonical() = %q, want %q", got, tt.want)
+			}
+			if v.String() != tt.s {
+				t.Errorf("String() = %q, want %q", v.String(), tt.s)
+			}
+		})
+	}
+}
+
+func TestFromSemVer(t *testing.T) {
+	tests := []struct {
+		s            string
+		want         string
+		wantSnapshot string
+	}{
+		{s: "v1.2.3", want: "1.2.3", wantSnapshot: "1.2.3"},
+		{s: "1.2.3-rc.1+build.5", want: "1.2.3-rc-1", wantSnapshot: "1.2.3-SNAPSHOT"},
+		{s: "1.2.4-dev.3", want: "1.2.4-dev-3", wantSnapshot: "1.2.4-SNAPSHOT"},
+		{s: "1.2.3+build.5", want: "1.2.3", wantSnapshot: "1.2.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			sv, err := semver.ParseSeparated(tt.s, "", "-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := FromSemVer(sv).String(); got != tt.want {
+				t.Errorf("FromSemVer() = %v, want %v", got, tt.want)
+			}
+			snapshot := SnapshotFromSemVer(sv)
+			if snapshot.String() != tt.wantSnapshot {
+				t.Errorf("SnapshotFromSemVer() = %v, want %v", snapshot, tt.wantSnapshot)
+			}
+			if snapshot.IsSnapshot() != (sv.Prerelease() != "") {
+				t.Errorf("IsSnapshot() = %v", snapshot.IsSnapshot())
+			}
+		})
+	}
+	// without a separator, the '-' is kept in the suffix
+	if got := FromSemVer(semver.MustParse("1.2.3-rc.1")).String(); got != "1.2.3-rc-1" {
+		t.Errorf("FromSemVer() = %v, want 1.2.3-rc-1", got)
+	}
+}
+
+func TestToSemVer(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantErr error
+	}{
+		{s: "1.2.3", want: "1.2.3"},
+		{s: "1.2", want: "1.2.0"},
+		{s: "1.2-beta-2", want: "1.2.0-beta.2"},
+		{s: "1.2.3.Final", want: "1.2.3"},
+		{s: "1.2.3-SNAPSHOT", want: "1.2.3-SNAPSHOT"},
+		{s: "1.0a1", want: "1.0.0-alpha.1"},
+		{s: "2.0.0-RC1", want: "2.0.0-rc.1"},
+		{s: "1.2.3.4", wantErr: ErrNotSemVer},
+		{s: "1.2.3-sp1", wantErr: ErrNotSemVer},
+		{s: "1.2.3-1", wantErr: ErrNotSemVer},
+		{s: "1.2.3-foo", wantErr: ErrNotSemVer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ToSemVer(Parse(tt.s))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ToSemVer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("ToSemVer() = %v, want %v", got, tt.want)
+			}
+			if Compare(FromSemVer(got), Parse(tt.s)) != 0 {
+				t.Errorf("FromSemVer(ToSemVer()) = %v, want equal to %v", FromSemVer(got), tt.s)
+			}
+		})
+	}
+}
+
+func TestVersion_zero(t *testing.T) {
+	var v Version
+	if v.Canonical() != "" || Compare(v, Parse("0")) != 0 {
+		t.Errorf("zero Version = %q, want the empty version", v.Canonical())
+	}
+}
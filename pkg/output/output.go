@@ -0,0 +1,171 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/adamhassel/semvergo/pkg/scheme"
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+// Supported output formats
+const (
+	Text         = "text"
+	JSON         = "json"
+	Env          = "env"
+	GitHub       = "github"
+	GitLabDotenv = "gitlab-dotenv"
+)
+
+// Formats lists all supported output formats
+var Formats = []string{Text, JSON, Env, GitHub, GitLabDotenv}
+
+var ErrUnknownFormat = errors.New("unknown output format")
+var ErrNoGitHubOutput = errors.New("GITHUB_OUTPUT is not set")
+
+// Result describes a computed version, and how it came about. Major, Minor and Patch are nil for schemes other than semver, and are left out of the output
+type Result struct {
+	Previous string `json:"previous"`
+	Version  string `json:"version"`
+	Bump     string `json:"bump"`
+	Major    *uint  `json:"major,omitempty"`
+	Minor    *uint  `json:"minor,omitempty"`
+	Patch    *uint  `json:"patch,omitempty"`
+	Prefix   string `json:"prefix"`
+	Suffix   string `json:"suffix"`
+	Tag      string `json:"tag"`
+	Reason   string `json:"reason"`
+	// Formatted is the version in an alternative format, like PEP 440. It is printed instead of Tag in the text format, if set
+	Formatted string `json:"formatted,omitempty"`
+}
+
+// NewResult returns a Result describing the step from prev to next
+func NewResult(prev, next semver.SemVer, bump, reason string) Result {
+	prefix, suffix := next.PreSuffix()
+	major, minor, patch := next.Major(), next.Minor(), next.Patch()
+	return Result{
+		Previous: prev.String(),
+		Version:  next.Version(),
+		Bump:     bump,
+		Major:    &major,
+		Minor:    &minor,
+		Patch:    &patch,
+		Prefix:   prefix,
+		Suffix:   suffix,
+		Tag:      next.String(),
+		Reason:   reason,
+	}
+}
+
+// NewSchemeResult returns a Result describing the step from prev to next in s. prev may be nil if there was no previous version. Semantic versions are described as by NewResult, for other schemes only the version strings are set, and the version numbers are nil
+func NewSchemeResult(s scheme.Scheme, prev, next scheme.Version, bump, reason string) Result {
+	if sv, ok := next.(semver.SemVer); ok {
+		if pv, ok := prev.(semver.SemVer); ok {
+			return NewResult(pv, sv, bump, reason)
+		}
+		r := NewResult(semver.SemVer{}, sv, bump, reason)
+		r.Previous = ""
+		return r
+	}
+	r := Result{
+		Version: s.Format(next),
+		Bump:    bump,
+		Tag:     s.Format(next),
+		Reason:  reason,
+	}
+	if prev != nil {
+		r.Previous = s.Format(prev)
+	}
+	if p, ok := next.(interface{ Prefix() string }); ok {
+		r.Prefix = p.Prefix()
+		r.Version = strings.TrimPrefix(r.Tag, r.Prefix)
+	}
+	return r
+}
+
+// pairs returns the key/value pairs of r, in a stable order. Keys are lower case. Unset version numbers are left out
+func (r Result) pairs() [][2]string {
+	rv := [][2]string{
+		{"version", r.Version},
+		{"previous", r.Previous},
+		{"tag", r.Tag},
+	}
+	for _, n := range []struct {
+		key   string
+		value *uint
+	}{{"major", r.Major}, {"minor", r.Minor}, {"patch", r.Patch}} {
+		if n.value != nil {
+			rv = append(rv, [2]string{n.key, strconv.FormatUint(uint64(*n.value), 10)})
+		}
+	}
+	rv = append(rv, [][2]string{
+		{"prefix", r.Prefix},
+		{"suffix", r.Suffix},
+		{"bump", r.Bump},
+		{"reason", r.Reason},
+	}...)
+	if r.Formatted != "" {
+		rv = append(rv, [2]string{"formatted", r.Formatted})
+	}
+	return rv
+}
+
+// Write writes r to w in the given format. For the GitHub format, w should be the file pointed to by $GITHUB_OUTPUT, see OpenGitHubOutput
+func Write(w io.Writer, format string, r Result) error {
+	switch format {
+	case Text, "":
+		s := r.Tag
+		if r.Formatted != "" {
+			s = r.Formatted
+		}
+		_, err := io.WriteString(w, s)
+		return err
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case Env:
+		return writePairs(w, r, "SEMVER_", shellQuote)
+	case GitLabDotenv:
+		return writePairs(w, r, "SEMVER_", nil)
+	case GitHub:
+		return writePairs(w, r, "", nil)
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// OpenGitHubOutput opens the file named by $GITHUB_OUTPUT for appending
+func OpenGitHubOutput() (*os.File, error) {
+	name := os.Getenv("GITHUB_OUTPUT")
+	if name == "" {
+		return nil, ErrNoGitHubOutput
+	}
+	return os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+}
+
+// writePairs writes one key=value line per field in r. Keys are upper cased and prefixed with prefix if it is non-empty, values are passed through quote if it is non-nil
+func writePairs(w io.Writer, r Result, prefix string, quote func(string) string) error {
+	for _, p := range r.pairs() {
+		k, v := p[0], p[1]
+		if prefix != "" {
+			k = prefix + strings.ToUpper(k)
+		}
+		if quote != nil {
+			v = quote(v)
+		}
+		if _, err := fmt.Fprintf(w, "%s=%s\n", k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shellQuote quotes s for safe use in a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,281 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adamhassel/semvergo/pkg/scheme"
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+func TestWrite(t *testing.T) {
+	prev, _ := semver.ParseSeparated("v1.2.3-dev", "", "-")
+	next := prev
+	next.IncrementMinor()
+	r := NewResult(prev, next, "minor", "test")
+
+	tests := []struct {
+		name    string
+		format  string
+		result  func(Result) Result
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "text",
+			format: Text,
+			want:   "v1.3.0-dev",
+		},
+		{
+			name:   "default is text",
+			format: "",
+			want:   "v1.3.0-dev",
+		},
+		{
+			name:   "json",
+			format: JSON,
+			want: `{
+  "previous": "v1.2.3-dev",
+  "version": "1.3.0",
+  "bump": "minor",
+  "major": 1,
+  "minor": 3,
+  "patch": 0,
+  "prefix": "v",
+  "suffix": "dev",
+  "tag": "v1.3.0-dev",
+  "reason": "test"
+}
+`,
+		},
+		{
+			name:   "env",
+			format: Env,
+			want: `SEMVER_VERSION='1.3.0'
+SEMVER_PREVIOUS='v1.2.3-dev'
+SEMVER_TAG='v1.3.0-dev'
+SEMVER_MAJOR='1'
+SEMVER_MINOR='3'
+SEMVER_PATCH='0'
+SEMVER_PREFIX='v'
+SEMVER_SUFFIX='dev'
+SEMVER_BUMP='minor'
+SEMVER_REASON='test'
+`,
+		},
+		{
+			name:   "gitlab dotenv",
+			format: GitLabDotenv,
+			want: `SEMVER_VERSION=1.3.0
+SEMVER_PREVIOUS=v1.2.3-dev
+SEMVER_TAG=v1.3.0-dev
+SEMVER_MAJOR=1
+SEMVER_MINOR=3
+SEMVER_PATCH=0
+SEMVER_PREFIX=v
+SEMVER_SUFFIX=dev
+SEMVER_BUMP=minor
+SEMVER_REASON=test
+`,
+		},
+		{
+			name:   "github",
+			format: GitHub,
+			want: `version=1.3.0
+previous=v1.2.3-dev
+tag=v1.3.0-dev
+major=1
+minor=3
+patch=0
+prefix=v
+suffix=dev
+bump=minor
+reason=test
+`,
+		},
+		{
+			name:   "formatted text",
+			format: Text,
+			result: func(r Result) Result {
+				r.Formatted = "1.3.0rc1"
+				return r
+			},
+			want: "1.3.0rc1",
+		},
+		{
+			name:   "formatted github",
+			format: GitHub,
+			result: func(r Result) Result {
+				r.Formatted = "1.3.0rc1"
+				return r
+			},
+			want: `version=1.3.0
+previous=v1.2.3-dev
+tag=v1.3.0-dev
+major=1
+minor=3
+patch=0
+prefix=v
+suffix=dev
+bump=minor
+reason=test
+formatted=1.3.0rc1
+`,
+		},
+		{
+			name:    "unknown",
+			format:  "yaml",
+			wantErr: ErrUnknownFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			r := r
+			if tt.result != nil {
+				r = tt.result(r)
+			}
+			err := Write(&b, tt.format, r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Write() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr(n uint) *uint {
+	return &n
+}
+
+func TestWrite_otherScheme(t *testing.T) {
+	nv := scheme.NewAssembly()
+	next, err := nv.Parse("v1.3.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewSchemeResult(nv, nil, next, "minor", "test")
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: JSON,
+			want: `{
+  "previous": "",
+  "version": "1.3.0.0",
+  "bump": "minor",
+  "prefix": "v",
+  "suffix": "",
+  "tag": "v1.3.0.0",
+  "reason": "test"
+}
+`,
+		},
+		{
+			format: GitHub,
+			want: `version=1.3.0.0
+previous=
+tag=v1.3.0.0
+prefix=v
+suffix=
+bump=minor
+reason=test
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := Write(&b, tt.format, r); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("Write() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSchemeResult(t *testing.T) {
+	sv := scheme.SemVer{}
+	nv := scheme.NewAssembly()
+	parse := func(s scheme.Scheme, str string) scheme.Version {
+		v, err := s.Parse(str)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	tests := []struct {
+		name   string
+		scheme scheme.Scheme
+		prev   scheme.Version
+		next   scheme.Version
+		want   Result
+	}{
+		{
+			name:   "semver",
+			scheme: sv,
+			prev:   parse(sv, "v1.2.3"),
+			next:   parse(sv, "v1.3.0-rc.1"),
+			want:   Result{Previous: "v1.2.3", Version: "1.3.0", Major: ptr(1), Minor: ptr(3), Patch: ptr(0), Prefix: "v", Suffix: "rc.1", Tag: "v1.3.0-rc.1", Bump: "minor"},
+		},
+		{
+			name:   "semver without previous",
+			scheme: sv,
+			next:   parse(sv, "0.0.1"),
+			want:   Result{Version: "0.0.1", Major: ptr(0), Minor: ptr(0), Patch: ptr(1), Tag: "0.0.1", Bump: "minor"},
+		},
+		{
+			name:   "other scheme",
+			scheme: nv,
+			prev:   parse(nv, "v1.2.3.4"),
+			next:   parse(nv, "v1.3.0.0"),
+			want:   Result{Previous: "v1.2.3.4", Version: "1.3.0.0", Prefix: "v", Tag: "v1.3.0.0", Bump: "minor"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSchemeResult(tt.scheme, tt.prev, tt.next, "minor", ""); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSchemeResult() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_shellQuote(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "plain",
+			s:    "1.2.3",
+			want: "'1.2.3'",
+		},
+		{
+			name: "empty",
+			s:    "",
+			want: "''",
+		},
+		{
+			name: "embedded quote",
+			s:    "it's",
+			want: `'it'\''s'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shellQuote(tt.s); got != tt.want {
+				t.Errorf("shellQuote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
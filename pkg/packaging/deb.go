@@ -0,0 +1,168 @@
+package packaging
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+var ErrInvalidDeb = errors.New("invalid Debian version")
+
+// Deb is a Debian package version, [epoch:]upstream[-revision]
+type Deb struct {
+	Epoch    uint
+	Upstream string
+	Revision string
+}
+
+// DebFromSemVer returns the Debian version of sv. The pre-release is separated by '~', so it sorts before the release, and build metadata is kept after a '+'. Any prefix is dropped. epoch is the Debian epoch, which is omitted if it is 0, and revision is the Debian revision, like "1". If it is empty, the package is native, and any '-' in the upstream version is replaced by '.', as it would otherwise be taken for a revision separator
+func DebFromSemVer(sv semver.SemVer, epoch uint, revision string) Deb {
+	upstream := sv.Version()
+	if pre := sv.Prerelease(); pre != "" {
+		upstream += "~" + pre
+	}
+	if build := sv.Build(); build != "" {
+		upstream += "+" + build
+	}
+	if revision == "" {
+		upstream = strings.ReplaceAll(upstream, "-", ".")
+	}
+	return Deb{Epoch: epoch, Upstream: upstream, Revision: revision}
+}
+
+// ParseDeb parses a Debian version
+func ParseDeb(s string) (Deb, error) {
+	var d Deb
+	if e, rest, ok := strings.Cut(s, ":"); ok {
+		n, err := strconv.ParseUint(e, 10, 32)
+		if err != nil {
+			return Deb{}, fmt.Errorf("%w: invalid epoch in %q", ErrInvalidDeb, s)
+		}
+		d.Epoch = uint(n)
+		s = rest
+	}
+	if i := strings.LastIndexByte(s, '-'); i >= 0 {
+		d.Revision = s[i+1:]
+		s = s[:i]
+		if d.Revision == "" || strings.Trim(d.Revision, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789+.~") != "" {
+			return Deb{}, fmt.Errorf("%w: invalid revision %q", ErrInvalidDeb, d.Revision)
+		}
+	}
+	if s == "" || s[0] < '0' || s[0] > '9' || strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789.+~-") != "" {
+		return Deb{}, fmt.Errorf("%w: invalid upstream version %q", ErrInvalidDeb, s)
+	}
+	d.Upstream = s
+	return d, nil
+}
+
+// String returns the Debian version string. The epoch is omitted if it is 0
+func (d Deb) String() string {
+	s := d.Upstream
+	if d.Epoch != 0 {
+		s = fmt.Sprintf("%d:%s", d.Epoch, s)
+	}
+	if d.Revision != "" {
+		s += "-" + d.Revision
+	}
+	return s
+}
+
+// SemVer converts d back to a semantic version, turning the first '~' into the pre-release separator. The epoch and revision are dropped
+func (d Deb) SemVer() (semver.SemVer, error) {
+	return semver.ParseSeparated(strings.Replace(d.Upstream, "~", "-", 1), "", "-")
+}
+
+// CompareDeb returns -1, 0 or 1 as a sorts before, equal to or after b, using dpkg's algorithm
+func CompareDeb(a, b Deb) int {
+	if a.Epoch != b.Epoch {
+		if a.Epoch < b.Epoch {
+			return -1
+		}
+		return 1
+	}
+	if c := verrevcmp(a.Upstream, b.Upstream); c != 0 {
+		return c
+	}
+	return verrevcmp(a.Revision, b.Revision)
+}
+
+// debOrder is the sort weight of a character in the non-digit part of a Debian version. '~' sorts before everything, even the end of the string, and letters sort before other characters
+func debOrder(c byte) int {
+	switch {
+	case isDigit(c):
+		return 0
+	case isAlpha(c):
+		return int(c)
+	case c == '~':
+		return -1
+	case c != 0:
+		return int(c) + 256
+	}
+	return 0
+}
+
+// verrevcmp is dpkg's comparison of upstream versions or revisions, alternately comparing non-digit parts by debOrder and digit parts numerically
+func verrevcmp(a, b string) int {
+	at := func(s string, i int) byte {
+		if i < len(s) {
+			return s[i]
+		}
+		return 0
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			ac, bc := debOrder(at(a, i)), debOrder(at(b, j))
+			if ac != bc {
+				return sign(ac - bc)
+			}
+			i++
+			j++
+		}
+		for at(a, i) == '0' {
+			i++
+		}
+		for at(b, j) == '0' {
+			j++
+		}
+		firstDiff := 0
+		for isDigit(at(a, i)) && isDigit(at(b, j)) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if isDigit(at(a, i)) {
+			return 1
+		}
+		if isDigit(at(b, j)) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return sign(firstDiff)
+		}
+	}
+	return 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
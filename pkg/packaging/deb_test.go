@@ -0,0 +1,158 @@
+package packaging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+// semverOrder is the precedence example from the semver spec, in ascending order
+var semverOrder = []string{
+	"1.0.0-alpha",
+	"1.0.0-alpha.1",
+	"1.0.0-alpha.beta",
+	"1.0.0-beta",
+	"1.0.0-beta.2",
+	"1.0.0-beta.11",
+	"1.0.0-rc.1",
+	"1.0.0",
+	"1.0.1",
+	"1.2.0-rc.1",
+	"1.2.0",
+	"1.10.0",
+	"2.0.0",
+}
+
+func parseAll(t *testing.T, vs []string) []semver.SemVer {
+	t.Helper()
+	var rv []semver.SemVer
+	for _, v := range vs {
+		sv, err := semver.ParseSeparated(v, "", "-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rv = append(rv, sv)
+	}
+	return rv
+}
+
+// checkOrder checks that cmp orders all pairs of vs the same as semver precedence
+func checkOrder(t *testing.T, vs []semver.SemVer, cmp func(a, b semver.SemVer) int) {
+	t.Helper()
+	for i := range vs {
+		for j := range vs {
+			want := semver.Compare(vs[i], vs[j])
+			if got := cmp(vs[i], vs[j]); got != want {
+				t.Errorf("compare(%s, %s) = %d, want %d", vs[i].String(), vs[j].String(), got, want)
+			}
+		}
+	}
+}
+
+func TestDebOrderMatchesSemVer(t *testing.T) {
+	checkOrder(t, parseAll(t, semverOrder), func(a, b semver.SemVer) int {
+		return CompareDeb(DebFromSemVer(a, 0, "1"), DebFromSemVer(b, 0, "1"))
+	})
+}
+
+func TestDebFromSemVer(t *testing.T) {
+	tests := []struct {
+		s        string
+		epoch    uint
+		revision string
+		want     string
+	}{
+		{s: "v1.2.3", revision: "1", want: "1.2.3-1"},
+		{s: "1.2.3-rc.1", revision: "1", want: "1.2.3~rc.1-1"},
+		{s: "1.2.3-rc.1+build.5", revision: "2", want: "1.2.3~rc.1+build.5-2"},
+		{s: "1.2.3-feature-x", revision: "1", want: "1.2.3~feature-x-1"},
+		{s: "1.2.3-feature-x", want: "1.2.3~feature.x"},
+		{s: "1.2.3", epoch: 1, revision: "1", want: "1:1.2.3-1"},
+		{s: "1.2.3-rc.1", epoch: 2, want: "2:1.2.3~rc.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			sv, err := semver.ParseSeparated(tt.s, "", "-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := DebFromSemVer(sv, tt.epoch, tt.revision)
+			if got.String() != tt.want {
+				t.Errorf("DebFromSemVer() = %v, want %v", got.String(), tt.want)
+			}
+			parsed, err := ParseDeb(got.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed != got {
+				t.Errorf("ParseDeb() = %+v, want %+v", parsed, got)
+			}
+		})
+	}
+}
+
+func TestParseDeb(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Deb
+		wantErr error
+	}{
+		{s: "1.2.3", want: Deb{Upstream: "1.2.3"}},
+		{s: "2:1.2.3~rc.1-0ubuntu1", want: Deb{Epoch: 2, Upstream: "1.2.3~rc.1", Revision: "0ubuntu1"}},
+		{s: "1.2-3-4", want: Deb{Upstream: "1.2-3", Revision: "4"}},
+		{s: "x:1.2.3", wantErr: ErrInvalidDeb},
+		{s: "v1.2.3", wantErr: ErrInvalidDeb},
+		{s: "1.2.3-", wantErr: ErrInvalidDeb},
+		{s: "1.2.3_4", wantErr: ErrInvalidDeb},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseDeb(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseDeb() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseDeb() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareDeb(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0", b: "1.0", want: 0},
+		{a: "1.0~rc1", b: "1.0", want: -1},
+		{a: "1.0~~", b: "1.0~", want: -1},
+		{a: "1.0", b: "1.0+b1", want: -1},
+		{a: "1.0", b: "1.0a", want: -1},
+		{a: "1.0a", b: "1.0.1", want: -1},
+		{a: "1.0.09", b: "1.0.10", want: -1},
+		{a: "1:0.1", b: "2.0", want: 1},
+		{a: "1.0-1", b: "1.0-2", want: -1},
+		{a: "1.0-10", b: "1.0-9", want: 1},
+		{a: "1.0-1", b: "1.0", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			a, err := ParseDeb(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ParseDeb(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := CompareDeb(a, b); got != tt.want {
+				t.Errorf("CompareDeb() = %v, want %v", got, tt.want)
+			}
+			if got := CompareDeb(b, a); got != -tt.want {
+				t.Errorf("CompareDeb() reversed = %v, want %v", got, -tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package packaging
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+var ErrInvalidRPM = errors.New("invalid RPM version")
+
+// RPM is an RPM package version, split into the Version and Release tags of a spec file
+type RPM struct {
+	Epoch   uint
+	Version string
+	Release string
+}
+
+// RPMFromSemVer returns the RPM version of sv. The pre-release is separated by '~', so it sorts before the release, and build metadata is kept after a '+'. Any prefix is dropped, and '-', which is not allowed in RPM versions, is replaced by '_'. epoch is the Epoch tag, which is omitted if it is 0, and release is the Release tag, like "1".
+//
+// Note that rpmvercmp sorts numeric segments after alphabetic ones, where semver does the opposite, so pre-releases with numeric and alphanumeric identifiers in the same position, like "alpha.1" and "alpha.beta", don't sort as they do in semver
+func RPMFromSemVer(sv semver.SemVer, epoch uint, release string) RPM {
+	version := sv.Version()
+	if pre := sv.Prerelease(); pre != "" {
+		version += "~" + pre
+	}
+	if build := sv.Build(); build != "" {
+		version += "+" + build
+	}
+	return RPM{Epoch: epoch, Version: strings.ReplaceAll(version, "-", "_"), Release: release}
+}
+
+// ParseRPM parses an RPM version of the form [epoch:]version[-release]
+func ParseRPM(s string) (RPM, error) {
+	var r RPM
+	if e, rest, ok := strings.Cut(s, ":"); ok {
+		n, err := strconv.ParseUint(e, 10, 32)
+		if err != nil {
+			return RPM{}, fmt.Errorf("%w: invalid epoch in %q", ErrInvalidRPM, s)
+		}
+		r.Epoch = uint(n)
+		s = rest
+	}
+	r.Version, r.Release, _ = strings.Cut(s, "-")
+	for _, part := range []string{r.Version, r.Release} {
+		if strings.Trim(part, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789._+~^") != "" {
+			return RPM{}, fmt.Errorf("%w: %q", ErrInvalidRPM, s)
+		}
+	}
+	if r.Version == "" {
+		return RPM{}, fmt.Errorf("%w: empty version", ErrInvalidRPM)
+	}
+	return r, nil
+}
+
+// String returns the version as [epoch:]version[-release]. The epoch is omitted if it is 0
+func (r RPM) String() string {
+	s := r.Version
+	if r.Epoch != 0 {
+		s = fmt.Sprintf("%d:%s", r.Epoch, s)
+	}
+	if r.Release != "" {
+		s += "-" + r.Release
+	}
+	return s
+}
+
+// SemVer converts r back to a semantic version, turning the first '~' into the pre-release separator and '_' into '-'. The epoch and release are dropped
+func (r RPM) SemVer() (semver.SemVer, error) {
+	v := strings.Replace(strings.ReplaceAll(r.Version, "_", "-"), "~", "-", 1)
+	return semver.ParseSeparated(v, "", "-")
+}
+
+// CompareRPM returns -1, 0 or 1 as a sorts before, equal to or after b, using rpm's algorithm
+func CompareRPM(a, b RPM) int {
+	if a.Epoch != b.Epoch {
+		if a.Epoch < b.Epoch {
+			return -1
+		}
+		return 1
+	}
+	if c := rpmvercmp(a.Version, b.Version); c != 0 {
+		return c
+	}
+	return rpmvercmp(a.Release, b.Release)
+}
+
+// rpmvercmp is rpm's version comparison. Versions are split into alternating runs of digits and letters, ignoring other characters. Digit runs compare numerically and sort after letter runs. '~' sorts before everything, even the end of the string, and '^' sorts after the end of the string, but before anything else
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	at := func(s string, i int) byte {
+		if i < len(s) {
+			return s[i]
+		}
+		return 0
+	}
+	isAlnum := func(c byte) bool {
+		return isDigit(c) || isAlpha(c)
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isAlnum(a[i]) && a[i] != '~' && a[i] != '^' {
+			i++
+		}
+		for j < len(b) && !isAlnum(b[j]) && b[j] != '~' && b[j] != '^' {
+			j++
+		}
+		ca, cb := at(a, i), at(b, j)
+		if ca == '~' || cb == '~' {
+			if ca != '~' {
+				return 1
+			}
+			if cb != '~' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+		if ca == '^' || cb == '^' {
+			switch {
+			case ca == 0:
+				return -1
+			case cb == 0:
+				return 1
+			case ca != '^':
+				return 1
+			case cb != '^':
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+		if ca == 0 || cb == 0 {
+			break
+		}
+		class := isAlpha
+		if isDigit(ca) {
+			class = isDigit
+		}
+		ei, ej := i, j
+		for ei < len(a) && class(a[ei]) {
+			ei++
+		}
+		for ej < len(b) && class(b[ej]) {
+			ej++
+		}
+		sa, sb := a[i:ei], b[j:ej]
+		if sb == "" {
+			// segments of different types, numeric is newer
+			if isDigit(ca) {
+				return 1
+			}
+			return -1
+		}
+		if isDigit(ca) {
+			sa, sb = strings.TrimLeft(sa, "0"), strings.TrimLeft(sb, "0")
+			if len(sa) != len(sb) {
+				return sign(len(sa) - len(sb))
+			}
+		}
+		if c := strings.Compare(sa, sb); c != 0 {
+			return c
+		}
+		i, j = ei, ej
+	}
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i >= len(a):
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,106 @@
+package packaging
+
+import (
+	"testing"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+func TestRPMOrderMatchesSemVer(t *testing.T) {
+	// rpmvercmp sorts numbers after letters, so alpha.1 and alpha.beta can't both be included
+	var vs []string
+	for _, v := range semverOrder {
+		if v != "1.0.0-alpha.beta" {
+			vs = append(vs, v)
+		}
+	}
+	checkOrder(t, parseAll(t, vs), func(a, b semver.SemVer) int {
+		return CompareRPM(RPMFromSemVer(a, 0, "1"), RPMFromSemVer(b, 0, "1"))
+	})
+}
+
+func TestRPMFromSemVer(t *testing.T) {
+	tests := []struct {
+		s       string
+		epoch   uint
+		release string
+		want    string
+	}{
+		{s: "v1.2.3", release: "1", want: "1.2.3-1"},
+		{s: "1.2.3-rc.1", release: "1", want: "1.2.3~rc.1-1"},
+		{s: "1.2.3-feature-x+build.5", release: "1", want: "1.2.3~feature_x+build.5-1"},
+		{s: "1.2.3", epoch: 1, release: "1", want: "1:1.2.3-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			sv, err := semver.ParseSeparated(tt.s, "", "-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := RPMFromSemVer(sv, tt.epoch, tt.release)
+			if got.String() != tt.want {
+				t.Errorf("RPMFromSemVer() = %v, want %v", got.String(), tt.want)
+			}
+			parsed, err := ParseRPM(got.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed != got {
+				t.Errorf("ParseRPM() = %+v, want %+v", parsed, got)
+			}
+			back, err := parsed.SemVer()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if semver.Compare(back, sv) != 0 {
+				t.Errorf("SemVer() = %v, want %v", back.String(), sv.String())
+			}
+		})
+	}
+}
+
+func TestCompareRPM(t *testing.T) {
+	// from rpm's test suite
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0", b: "1.0", want: 0},
+		{a: "1.0", b: "2.0", want: -1},
+		{a: "2.0.1", b: "2.0", want: 1},
+		{a: "2.0.1a", b: "2.0.1", want: 1},
+		{a: "5.5p1", b: "5.5p2", want: -1},
+		{a: "5.5p10", b: "5.5p1", want: 1},
+		{a: "10xyz", b: "10.1xyz", want: -1},
+		{a: "xyz10", b: "xyz10.1", want: -1},
+		{a: "xyz.4", b: "8", want: -1},
+		{a: "2a", b: "2.0", want: -1},
+		{a: "1.0~rc1", b: "1.0", want: -1},
+		{a: "1.0~rc1", b: "1.0~rc2", want: -1},
+		{a: "1.0~rc1~git123", b: "1.0~rc1", want: -1},
+		{a: "1.0^", b: "1.0", want: 1},
+		{a: "1.0^git1", b: "1.0.1", want: -1},
+		{a: "1.0^git1~pre", b: "1.0^git1", want: -1},
+		{a: "1:1.0", b: "2.0", want: 1},
+		{a: "1.0-1", b: "1.0-2", want: -1},
+		{a: "1.0-2", b: "1.0-10", want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			a, err := ParseRPM(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ParseRPM(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := CompareRPM(a, b); got != tt.want {
+				t.Errorf("CompareRPM() = %v, want %v", got, tt.want)
+			}
+			if got := CompareRPM(b, a); got != -tt.want {
+				t.Errorf("CompareRPM() reversed = %v, want %v", got, -tt.want)
+			}
+		})
+	}
+}
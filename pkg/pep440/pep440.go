@@ -0,0 +1,315 @@
+package pep440
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+var ErrParsingError = errors.New("not a PEP 440 version")
+var ErrUnsupportedLabel = errors.New("pre-release label has no PEP 440 equivalent")
+var ErrNotSemVer = errors.New("PEP 440 version has no semver equivalent")
+
+// versionRE is the version pattern from PEP 440, appendix B
+var versionRE = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?\s*$`)
+
+// preLabels maps all accepted spellings of pre-release labels to their normalized form
+var preLabels = map[string]string{
+	"a": "a", "alpha": "a",
+	"b": "b", "beta": "b",
+	"rc": "rc", "c": "rc", "pre": "rc", "preview": "rc",
+}
+
+// Version is a PEP 440 version
+type Version struct {
+	Epoch   uint
+	Release []uint
+	// Pre is the normalized pre-release label, one of "a", "b" or "rc", or empty for no pre-release
+	Pre     string
+	PreN    uint
+	HasPost bool
+	Post    uint
+	HasDev  bool
+	Dev     uint
+	// Local is the normalized local version label, without the '+'
+	Local string
+}
+
+// Parse parses a PEP 440 version, normalizing it
+func Parse(s string) (Version, error) {
+	m := versionRE.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("%w: %q", ErrParsingError, s)
+	}
+	group := func(name string) string {
+		return m[versionRE.SubexpIndex(name)]
+	}
+	var v Version
+	var err error
+	if e := group("epoch"); e != "" {
+		if v.Epoch, err = number(e); err != nil {
+			return Version{}, err
+		}
+	}
+	for _, r := range strings.Split(group("release"), ".") {
+		n, err := number(r)
+		if err != nil {
+			return Version{}, err
+		}
+		v.Release = append(v.Release, n)
+	}
+	if l := group("pre_l"); l != "" {
+		v.Pre = preLabels[strings.ToLower(l)]
+		if v.PreN, err = number(group("pre_n")); err != nil {
+			return Version{}, err
+		}
+	}
+	if n := group("post_n1"); n != "" {
+		v.HasPost = true
+		if v.Post, err = number(n); err != nil {
+			return Version{}, err
+		}
+	} else if group("post_l") != "" {
+		v.HasPost = true
+		if v.Post, err = number(group("post_n2")); err != nil {
+			return Version{}, err
+		}
+	}
+	if group("dev_l") != "" {
+		v.HasDev = true
+		if v.Dev, err = number(group("dev_n")); err != nil {
+			return Version{}, err
+		}
+	}
+	v.Local = strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' {
+			return '.'
+		}
+		return r
+	}, strings.ToLower(group("local")))
+	return v, nil
+}
+
+// number parses a decimal number, where the empty string is 0
+func number(s string) (uint, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrParsingError, err)
+	}
+	return uint(n), nil
+}
+
+// String returns the canonical form of v
+func (v Version) String() string {
+	b := strings.Builder{}
+	if v.Epoch != 0 {
+		fmt.Fprintf(&b, "%d!", v.Epoch)
+	}
+	for i, r := range v.Release {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.FormatUint(uint64(r), 10))
+	}
+	if v.Pre != "" {
+		fmt.Fprintf(&b, "%s%d", v.Pre, v.PreN)
+	}
+	if v.HasPost {
+		fmt.Fprintf(&b, ".post%d", v.Post)
+	}
+	if v.HasDev {
+		fmt.Fprintf(&b, ".dev%d", v.Dev)
+	}
+	if v.Local != "" {
+		b.WriteByte('+')
+		b.WriteString(v.Local)
+	}
+	return b.String()
+}
+
+// preRank orders pre-release labels. A dev release without a pre-release or post-release sorts before all pre-releases, and a version without a pre-release after them
+func (v Version) preRank() int {
+	switch {
+	case v.Pre == "" && !v.HasPost && v.HasDev:
+		return -1
+	case v.Pre == "":
+		return 3
+	case v.Pre == "a":
+		return 0
+	case v.Pre == "b":
+		return 1
+	}
+	return 2
+}
+
+// Compare returns -1, 0 or 1 as a sorts before, equal to or after b, according to PEP 440
+func Compare(a, b Version) int {
+	if c := cmp.Compare(a.Epoch, b.Epoch); c != 0 {
+		return c
+	}
+	for i := 0; i < max(len(a.Release), len(b.Release)); i++ {
+		var ra, rb uint
+		if i < len(a.Release) {
+			ra = a.Release[i]
+		}
+		if i < len(b.Release) {
+			rb = b.Release[i]
+		}
+		if c := cmp.Compare(ra, rb); c != 0 {
+			return c
+		}
+	}
+	if c := cmp.Compare(a.preRank(), b.preRank()); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.PreN, b.PreN); c != 0 {
+		return c
+	}
+	// no post-release sorts before any post-release
+	if c := compareOptional(a.HasPost, a.Post, b.HasPost, b.Post, false); c != 0 {
+		return c
+	}
+	// no dev release sorts after any dev release
+	if c := compareOptional(a.HasDev, a.Dev, b.HasDev, b.Dev, true); c != 0 {
+		return c
+	}
+	return compareLocal(a.Local, b.Local)
+}
+
+// compareOptional compares two optional numbers. If missingHigh is true, a missing number sorts after any number, otherwise before
+func compareOptional(hasA bool, a uint, hasB bool, b uint, missingHigh bool) int {
+	switch {
+	case hasA && hasB:
+		return cmp.Compare(a, b)
+	case hasA == hasB:
+		return 0
+	case hasA == missingHigh:
+		return -1
+	}
+	return 1
+}
+
+// compareLocal compares local version labels. No label sorts first, numeric segments sort after alphanumeric ones and are compared numerically, and if all segments are equal, the longer label sorts last
+func compareLocal(a, b string) int {
+	if a == "" || b == "" {
+		return cmp.Compare(len(a), len(b))
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		na, ea := strconv.ParseUint(as[i], 10, 64)
+		nb, eb := strconv.ParseUint(bs[i], 10, 64)
+		var c int
+		switch {
+		case ea == nil && eb == nil:
+			c = cmp.Compare(na, nb)
+		case ea == nil:
+			c = 1
+		case eb == nil:
+			c = -1
+		default:
+			c = cmp.Compare(as[i], bs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(as), len(bs))
+}
+
+// semverLabels maps semver pre-release identifiers to PEP 440 labels. "dev" and "post" are handled separately
+var semverLabels = map[string]string{
+	"alpha": "a", "a": "a",
+	"beta": "b", "b": "b",
+	"rc": "rc", "c": "rc", "pre": "rc", "preview": "rc",
+}
+
+// labelRE splits a pre-release identifier like "rc1" into label and number
+var labelRE = regexp.MustCompile(`^([a-zA-Z]+)([0-9]*)$`)
+
+// FromSemVer converts sv to a PEP 440 version. Pre-release labels alpha, beta and rc (and their short forms) map to a, b and rc, and dev and post map to dev and post releases. Each label may be followed by a number, either in the same identifier or the next, like "rc1" or "rc.1". Build metadata becomes the local version label. Any prefix is dropped
+func FromSemVer(sv semver.SemVer) (Version, error) {
+	v := Version{Release: []uint{sv.Major(), sv.Minor(), sv.Patch()}}
+	ids := strings.Split(sv.Prerelease(), ".")
+	if len(ids) == 1 && ids[0] == "" {
+		ids = nil
+	}
+	for i := 0; i < len(ids); i++ {
+		m := labelRE.FindStringSubmatch(ids[i])
+		if m == nil {
+			return Version{}, fmt.Errorf("%w: %q", ErrUnsupportedLabel, ids[i])
+		}
+		label, num := strings.ToLower(m[1]), m[2]
+		if num == "" && i+1 < len(ids) {
+			if _, err := strconv.ParseUint(ids[i+1], 10, 32); err == nil {
+				num = ids[i+1]
+				i++
+			}
+		}
+		n, err := number(num)
+		if err != nil {
+			return Version{}, err
+		}
+		switch {
+		case label == "dev" && !v.HasDev:
+			v.HasDev, v.Dev = true, n
+		case label == "post" && !v.HasPost && !v.HasDev:
+			v.HasPost, v.Post = true, n
+		case semverLabels[label] != "" && v.Pre == "" && !v.HasPost && !v.HasDev:
+			v.Pre, v.PreN = semverLabels[label], n
+		default:
+			return Version{}, fmt.Errorf("%w: %q", ErrUnsupportedLabel, sv.Prerelease())
+		}
+	}
+	if build := sv.Build(); build != "" {
+		local, err := Parse("0+" + build)
+		if err != nil {
+			return Version{}, fmt.Errorf("%w: invalid local version %q", ErrUnsupportedLabel, build)
+		}
+		v.Local = local.Local
+	}
+	return v, nil
+}
+
+// pep440Labels maps PEP 440 pre-release labels to semver identifiers
+var pep440Labels = map[string]string{"a": "alpha", "b": "beta", "rc": "rc"}
+
+// ToSemVer converts v to a semantic version, the reverse of FromSemVer. Versions with an epoch or more than three release components can't be converted. Pre-release labels become "alpha.N", "beta.N" and "rc.N", post and dev releases become "post.N" and "dev.N", and the local version label becomes build metadata
+func ToSemVer(v Version) (semver.SemVer, error) {
+	if v.Epoch != 0 || len(v.Release) > 3 {
+		return semver.SemVer{}, fmt.Errorf("%w: %s", ErrNotSemVer, v.String())
+	}
+	var r [3]uint
+	copy(r[:], v.Release)
+	s := fmt.Sprintf("%d.%d.%d", r[0], r[1], r[2])
+	var pre []string
+	if v.Pre != "" {
+		pre = append(pre, fmt.Sprintf("%s.%d", pep440Labels[v.Pre], v.PreN))
+	}
+	if v.HasPost {
+		pre = append(pre, fmt.Sprintf("post.%d", v.Post))
+	}
+	if v.HasDev {
+		pre = append(pre, fmt.Sprintf("dev.%d", v.Dev))
+	}
+	if len(pre) > 0 {
+		s += "-" + strings.Join(pre, ".")
+	}
+	if v.Local != "" {
+		s += "+" + v.Local
+	}
+	return semver.ParseSeparated(s, "", "-")
+}
@@ -0,0 +1,177 @@
+package pep440
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantErr error
+	}{
+		{s: "1.2.3", want: "1.2.3"},
+		{s: "v1.2.3", want: "1.2.3"},
+		{s: "01.02.003", want: "1.2.3"},
+		{s: "1!2.0", want: "1!2.0"},
+		{s: "1.2.3rc1", want: "1.2.3rc1"},
+		{s: "1.2.3-RC.1", want: "1.2.3rc1"},
+		{s: "1.2.3.alpha", want: "1.2.3a0"},
+		{s: "1.2.3beta2", want: "1.2.3b2"},
+		{s: "1.2.3c1", want: "1.2.3rc1"},
+		{s: "1.2.3-preview.4", want: "1.2.3rc4"},
+		{s: "1.2.3-1", want: "1.2.3.post1"},
+		{s: "1.2.3.rev2", want: "1.2.3.post2"},
+		{s: "1.2.3post", want: "1.2.3.post0"},
+		{s: "1.2.3.dev4", want: "1.2.3.dev4"},
+		{s: "1.2.3-dev", want: "1.2.3.dev0"},
+		{s: "1.2.3rc1.post2.dev3", want: "1.2.3rc1.post2.dev3"},
+		{s: "1.2.3+Local-Build_5", want: "1.2.3+local.build.5"},
+		{s: " 1.2 ", want: "1.2"},
+		{s: "1.2.3-foo", wantErr: ErrParsingError},
+		{s: "", wantErr: ErrParsingError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := Parse(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Parse() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// ascending order, from the PEP 440 examples
+	versions := []string{
+		"1.0.dev456",
+		"1.0a1",
+		"1.0a2.dev456",
+		"1.0a12.dev456",
+		"1.0a12",
+		"1.0b1.dev456",
+		"1.0b2",
+		"1.0b2.post345.dev456",
+		"1.0b2.post345",
+		"1.0rc1.dev456",
+		"1.0rc1",
+		"1.0",
+		"1.0+abc.5",
+		"1.0+abc.7",
+		"1.0+5",
+		"1.0.post456.dev34",
+		"1.0.post456",
+		"1.0.15",
+		"1.1.dev1",
+		"1!0.1",
+	}
+	for i := 1; i < len(versions); i++ {
+		a, err := Parse(versions[i-1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := Parse(versions[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Compare(a, b); got != -1 {
+			t.Errorf("Compare(%s, %s) = %d, want -1", versions[i-1], versions[i], got)
+		}
+		if got := Compare(b, a); got != 1 {
+			t.Errorf("Compare(%s, %s) = %d, want 1", versions[i], versions[i-1], got)
+		}
+	}
+	a, _ := Parse("1.0")
+	b, _ := Parse("1.0.0")
+	if got := Compare(a, b); got != 0 {
+		t.Errorf("Compare(1.0, 1.0.0) = %d, want 0", got)
+	}
+}
+
+func TestFromSemVer(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantErr error
+	}{
+		{s: "v1.2.3", want: "1.2.3"},
+		{s: "1.2.3-rc.1", want: "1.2.3rc1"},
+		{s: "1.2.3-rc1", want: "1.2.3rc1"},
+		{s: "1.2.3-alpha", want: "1.2.3a0"},
+		{s: "1.2.3-beta.2", want: "1.2.3b2"},
+		{s: "1.2.3-dev.4", want: "1.2.3.dev4"},
+		{s: "1.2.3-rc.1.dev.4", want: "1.2.3rc1.dev4"},
+		{s: "1.2.3-post.1", want: "1.2.3.post1"},
+		{s: "1.2.3+local.7", want: "1.2.3+local.7"},
+		{s: "1.2.3-rc.1+build-5", want: "1.2.3rc1+build.5"},
+		{s: "1.2.3-feature", wantErr: ErrUnsupportedLabel},
+		{s: "1.2.3-rc.1.rc.2", wantErr: ErrUnsupportedLabel},
+		{s: "1.2.3-dev.1.rc.2", wantErr: ErrUnsupportedLabel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			sv, err := semver.ParseSeparated(tt.s, "", "-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := FromSemVer(sv)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FromSemVer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("FromSemVer() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestToSemVer(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantErr error
+	}{
+		{s: "1.2.3", want: "1.2.3"},
+		{s: "1.2", want: "1.2.0"},
+		{s: "1.2.3rc1", want: "1.2.3-rc.1"},
+		{s: "1.2.3a2.dev4", want: "1.2.3-alpha.2.dev.4"},
+		{s: "1.2.3.post1", want: "1.2.3-post.1"},
+		{s: "1.2.3+local.7", want: "1.2.3+local.7"},
+		{s: "1!1.2.3", wantErr: ErrNotSemVer},
+		{s: "1.2.3.4", wantErr: ErrNotSemVer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			v, err := Parse(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ToSemVer(v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToSemVer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ToSemVer() = %v, want %v", got.String(), tt.want)
+			}
+			if err != nil {
+				return
+			}
+			back, err := FromSemVer(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if Compare(back, v) != 0 {
+				t.Errorf("FromSemVer(ToSemVer(%s)) = %s", tt.s, back.String())
+			}
+		})
+	}
+}
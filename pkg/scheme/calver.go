@@ -0,0 +1,237 @@
+package scheme
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidFormat = errors.New("invalid CalVer format")
+
+// calTokenRE matches the tokens of a CalVer format string. Longer tokens come first, so YYYY isn't taken for YY
+var calTokenRE = regexp.MustCompile(`YYYY|0Y|YY|0M|MM|0W|WW|0D|DD|MAJOR|MINOR|MICRO`)
+
+// calPatterns are the regular expressions matching each token
+var calPatterns = map[string]string{
+	"YYYY": `[0-9]{4}`, "YY": `[0-9]{1,3}`, "0Y": `[0-9]{2,3}`,
+	"MM": `[0-9]{1,2}`, "0M": `[0-9]{2}`,
+	"WW": `[0-9]{1,2}`, "0W": `[0-9]{2}`,
+	"DD": `[0-9]{1,2}`, "0D": `[0-9]{2}`,
+	"MAJOR": `[0-9]+`, "MINOR": `[0-9]+`, "MICRO": `[0-9]+`,
+}
+
+// counters are the non-date tokens, most significant first
+var counters = []string{"MAJOR", "MINOR", "MICRO"}
+
+// CalVer is the calendar versioning scheme, see https://calver.org. The format is a string of tokens separated by non-digit literals, like "YYYY.0M.MICRO". Date tokens are YYYY (full year), YY and 0Y (year since 2000, 0Y zero-padded), MM and 0M (month), WW and 0W (ISO week) and DD and 0D (day of month). Counter tokens are MAJOR, MINOR and MICRO.
+//
+// Bumping a version rolls the date tokens to today, resetting all counters, or, if the date is still current, increments a counter.
+type CalVer struct {
+	format string
+	tokens []string
+	seps   []string
+	re     *regexp.Regexp
+	// Now returns the current time, and defaults to time.Now. Dates are computed in UTC
+	Now func() time.Time
+}
+
+// CalVerVersion is a version in a CalVer scheme
+type CalVerVersion struct {
+	prefix string
+	values []int
+	scheme *CalVer
+}
+
+// NewCalVer returns a CalVer scheme using format
+func NewCalVer(format string) (*CalVer, error) {
+	c := &CalVer{format: format, Now: time.Now}
+	idx := calTokenRE.FindAllStringIndex(format, -1)
+	if len(idx) == 0 || idx[0][0] != 0 || idx[len(idx)-1][1] != len(format) {
+		return nil, fmt.Errorf("%w: %q must start and end with a token", ErrInvalidFormat, format)
+	}
+	re := strings.Builder{}
+	re.WriteString(`^([^0-9]*)`)
+	date := false
+	for i, m := range idx {
+		sep := ""
+		if i > 0 {
+			sep = format[idx[i-1][1]:m[0]]
+			if sep == "" || strings.ContainsAny(sep, "0123456789") {
+				return nil, fmt.Errorf("%w: tokens in %q must be separated by non-digits", ErrInvalidFormat, format)
+			}
+		}
+		tok := format[m[0]:m[1]]
+		if slices.Contains(c.tokens, tok) {
+			return nil, fmt.Errorf("%w: %s is repeated in %q", ErrInvalidFormat, tok, format)
+		}
+		date = date || !slices.Contains(counters, tok)
+		c.tokens = append(c.tokens, tok)
+		c.seps = append(c.seps, sep)
+		re.WriteString(regexp.QuoteMeta(sep) + "(" + calPatterns[tok] + ")")
+	}
+	if !date {
+		return nil, fmt.Errorf("%w: %q has no date tokens", ErrInvalidFormat, format)
+	}
+	re.WriteString("$")
+	c.re = regexp.MustCompile(re.String())
+	return c, nil
+}
+
+// String returns the format of c
+func (c *CalVer) String() string {
+	return c.format
+}
+
+// Parse parses a version in the format of c. Any non-digit prefix, like "v", is kept
+func (c *CalVer) Parse(s string) (Version, error) {
+	m := c.re.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("%w: %q doesn't match %s", ErrParsingError, s, c.format)
+	}
+	v := CalVerVersion{prefix: m[1], scheme: c}
+	for i, tok := range c.tokens {
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrParsingError, err)
+		}
+		if !validDate(tok, n) {
+			return nil, fmt.Errorf("%w: %d is not a valid %s in %q", ErrParsingError, n, tok, s)
+		}
+		v.values = append(v.values, n)
+	}
+	return v, nil
+}
+
+// validDate checks the range of date token values
+func validDate(tok string, n int) bool {
+	switch tok {
+	case "MM", "0M":
+		return n >= 1 && n <= 12
+	case "WW", "0W":
+		return n >= 1 && n <= 53
+	case "DD", "0D":
+		return n >= 1 && n <= 31
+	}
+	return true
+}
+
+// version asserts that v is a version of c
+func (c *CalVer) version(v Version) CalVerVersion {
+	cv, ok := v.(CalVerVersion)
+	if !ok || cv.scheme != c {
+		panic(fmt.Sprintf("scheme: %T %v is not a version of CalVer %s", v, v, c.format))
+	}
+	return cv
+}
+
+// Compare compares a and b token by token
+func (c *CalVer) Compare(a, b Version) int {
+	return slices.Compare(c.version(a).values, c.version(b).values)
+}
+
+// Bump rolls the date of v to today and resets all counters, if the date of v is in the past. Otherwise, the counter for level is incremented, and less significant counters are reset. If the format has no counter for level, the least significant counter is used
+func (c *CalVer) Bump(v Version, level Level) (Version, error) {
+	today := c.today()
+	if v == nil {
+		return today, nil
+	}
+	cv := c.version(v)
+	if c.compareDates(today, cv) > 0 {
+		today.prefix = cv.prefix
+		return today, nil
+	}
+
+	want := counters[int(Major-level)]
+	target := -1
+	for i, tok := range c.tokens {
+		if tok == want {
+			target = i
+			break
+		}
+		if slices.Contains(counters, tok) && (target < 0 || slices.Index(counters, tok) > slices.Index(counters, c.tokens[target])) {
+			target = i
+		}
+	}
+	if target < 0 {
+		return nil, fmt.Errorf("%w: %s has no counter, and %s is still current", ErrCannotBump, c.format, cv.String())
+	}
+	rv := CalVerVersion{prefix: cv.prefix, values: slices.Clone(cv.values), scheme: c}
+	rv.values[target]++
+	for i, tok := range c.tokens {
+		if slices.Index(counters, tok) > slices.Index(counters, c.tokens[target]) {
+			rv.values[i] = 0
+		}
+	}
+	return rv, nil
+}
+
+// today returns the version for the current date, with all counters at 0. With a week token, the year is the ISO week-numbering year, so 2027-01-01 is in week 53 of 2026
+func (c *CalVer) today() CalVerVersion {
+	now := c.Now().UTC()
+	year := now.Year()
+	isoYear, week := now.ISOWeek()
+	if slices.Contains(c.tokens, "WW") || slices.Contains(c.tokens, "0W") {
+		year = isoYear
+	}
+	v := CalVerVersion{scheme: c}
+	for _, tok := range c.tokens {
+		n := 0
+		switch tok {
+		case "YYYY":
+			n = year
+		case "YY", "0Y":
+			n = year - 2000
+		case "MM", "0M":
+			n = int(now.Month())
+		case "WW", "0W":
+			n = week
+		case "DD", "0D":
+			n = now.Day()
+		}
+		v.values = append(v.values, n)
+	}
+	return v
+}
+
+// compareDates compares only the date tokens of a and b
+func (c *CalVer) compareDates(a, b CalVerVersion) int {
+	for i, tok := range c.tokens {
+		if slices.Contains(counters, tok) {
+			continue
+		}
+		if r := cmp.Compare(a.values[i], b.values[i]); r != 0 {
+			return r
+		}
+	}
+	return 0
+}
+
+// Format formats v according to the format of c
+func (c *CalVer) Format(v Version) string {
+	cv := c.version(v)
+	b := strings.Builder{}
+	b.WriteString(cv.prefix)
+	for i, tok := range c.tokens {
+		b.WriteString(c.seps[i])
+		if tok[0] == '0' {
+			fmt.Fprintf(&b, "%02d", cv.values[i])
+		} else {
+			b.WriteString(strconv.Itoa(cv.values[i]))
+		}
+	}
+	return b.String()
+}
+
+func (v CalVerVersion) String() string {
+	return v.scheme.Format(v)
+}
+
+// Prefix returns the non-digit prefix of v, like "v"
+func (v CalVerVersion) Prefix() string {
+	return v.prefix
+}
@@ -0,0 +1,224 @@
+package scheme
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func testCalVer(t *testing.T, format string) *CalVer {
+	t.Helper()
+	c, err := NewCalVer(format)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Now = func() time.Time {
+		return time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	}
+	return c
+}
+
+func TestNewCalVer(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr error
+	}{
+		{format: "YYYY.0M.MICRO"},
+		{format: "YY.0M.0D"},
+		{format: "YYYY.WW.MICRO"},
+		{format: "YYYY-MM-DD_MICRO"},
+		{format: "MAJOR.MINOR", wantErr: ErrInvalidFormat},
+		{format: "vYYYY.MM", wantErr: ErrInvalidFormat},
+		{format: "YYYY0M", wantErr: ErrInvalidFormat},
+		{format: "YYYY.MM.MM", wantErr: ErrInvalidFormat},
+		{format: "", wantErr: ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if _, err := NewCalVer(tt.format); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewCalVer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalVer_Parse(t *testing.T) {
+	tests := []struct {
+		format  string
+		s       string
+		want    string
+		wantErr error
+	}{
+		{format: "YYYY.0M.MICRO", s: "2026.10.3", want: "2026.10.3"},
+		{format: "YYYY.0M.MICRO", s: "v2026.10.3", want: "v2026.10.3"},
+		{format: "YYYY.0M.MICRO", s: "2026.1.3", wantErr: ErrParsingError},
+		{format: "YYYY.0M.MICRO", s: "2026.13.3", wantErr: ErrParsingError},
+		{format: "YY.0M.MICRO", s: "26.10.0", want: "26.10.0"},
+		{format: "YYYY.WW.MICRO", s: "2026.42.1", want: "2026.42.1"},
+		{format: "YYYY.MM.MICRO", s: "2026.09.1", want: "2026.9.1"},
+		{format: "YYYY.0M.MICRO", s: "1.2.3", wantErr: ErrParsingError},
+		{format: "YYYY.0M.MICRO", s: "2026.10.3-rc1", wantErr: ErrParsingError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.s, func(t *testing.T) {
+			c := testCalVer(t, tt.format)
+			got, err := c.Parse(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && c.Format(got) != tt.want {
+				t.Errorf("Parse() = %v, want %v", c.Format(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestCalVer_Compare(t *testing.T) {
+	c := testCalVer(t, "YYYY.0M.MICRO")
+	// ascending order
+	versions := []string{"2025.12.9", "2026.01.0", "2026.01.2", "2026.01.10", "2026.10.0"}
+	for i := 1; i < len(versions); i++ {
+		a, _ := c.Parse(versions[i-1])
+		b, _ := c.Parse(versions[i])
+		if got := c.Compare(a, b); got != -1 {
+			t.Errorf("Compare(%s, %s) = %d, want -1", versions[i-1], versions[i], got)
+		}
+		if got := c.Compare(b, a); got != 1 {
+			t.Errorf("Compare(%s, %s) = %d, want 1", versions[i], versions[i-1], got)
+		}
+	}
+}
+
+func TestCalVer_Bump(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		v       string
+		level   Level
+		now     time.Time
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "initial",
+			format: "YYYY.0M.MICRO",
+			want:   "2026.10.0",
+		},
+		{
+			name:   "new month",
+			format: "YYYY.0M.MICRO",
+			v:      "v2026.09.4",
+			want:   "v2026.10.0",
+		},
+		{
+			name:   "same month",
+			format: "YYYY.0M.MICRO",
+			v:      "2026.10.4",
+			want:   "2026.10.5",
+		},
+		{
+			name:   "short year",
+			format: "YY.0M.MICRO",
+			v:      "26.10.0",
+			want:   "26.10.1",
+		},
+		{
+			name:   "week",
+			format: "YYYY.WW.MICRO",
+			v:      "2026.41.3",
+			want:   "2026.42.0",
+		},
+		{
+			name:   "minor resets micro",
+			format: "YYYY.MINOR.MICRO",
+			v:      "2026.1.3",
+			level:  Minor,
+			want:   "2026.2.0",
+		},
+		{
+			name:   "major falls back to least significant counter",
+			format: "YYYY.0M.MICRO",
+			v:      "2026.10.3",
+			level:  Major,
+			want:   "2026.10.4",
+		},
+		{
+			name:   "future date increments",
+			format: "YYYY.0M.MICRO",
+			v:      "2026.11.3",
+			want:   "2026.11.4",
+		},
+		{
+			name:    "no counter",
+			format:  "YYYY.0M.0D",
+			v:       "2026.10.17",
+			wantErr: ErrCannotBump,
+		},
+		{
+			name:   "no counter, new day",
+			format: "YYYY.0M.0D",
+			v:      "2026.10.16",
+			want:   "2026.10.17",
+		},
+		{
+			name:   "week in the previous ISO year",
+			format: "YYYY.0W.MICRO",
+			now:    time.Date(2027, 1, 1, 12, 0, 0, 0, time.UTC),
+			want:   "2026.53.0",
+		},
+		{
+			name:   "same week across new year",
+			format: "YYYY.0W.MICRO",
+			v:      "2026.53.0",
+			now:    time.Date(2027, 1, 3, 12, 0, 0, 0, time.UTC),
+			want:   "2026.53.1",
+		},
+		{
+			name:   "first week of the ISO year",
+			format: "YYYY.0W.MICRO",
+			v:      "2026.53.1",
+			now:    time.Date(2027, 1, 8, 12, 0, 0, 0, time.UTC),
+			want:   "2027.01.0",
+		},
+		{
+			name:   "week in the next ISO year",
+			format: "YY.0W.MICRO",
+			v:      "25.52.2",
+			now:    time.Date(2025, 12, 29, 12, 0, 0, 0, time.UTC),
+			want:   "26.01.0",
+		},
+		{
+			name:   "calendar year without a week",
+			format: "YYYY.0M.MICRO",
+			v:      "2026.12.3",
+			now:    time.Date(2027, 1, 1, 12, 0, 0, 0, time.UTC),
+			want:   "2027.01.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testCalVer(t, tt.format)
+			if !tt.now.IsZero() {
+				c.Now = func() time.Time {
+					return tt.now
+				}
+			}
+			var v Version
+			if tt.v != "" {
+				var err error
+				if v, err = c.Parse(tt.v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := c.Bump(v, tt.level)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Bump() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Bump() = %v, want %v", got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package scheme
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidComponents = errors.New("invalid number of version components")
+
+// AssemblyMax is the highest value of a component of a .NET assembly version
+const AssemblyMax = 65534
+
+// Numeric is a versioning scheme of a fixed number of dot separated numeric components, like 1.2.3.4. A non-digit prefix, like "v", is kept.
+//
+// Major and minor bumps increment the first and second component, and patch bumps increment the last, resetting all components after the incremented one
+type Numeric struct {
+	n   int
+	max uint64
+}
+
+// NumericVersion is a version in a Numeric scheme
+type NumericVersion struct {
+	prefix string
+	values []uint64
+	scheme *Numeric
+}
+
+// NewNumeric returns a scheme of n components. If max is not 0, it is the highest value of any component
+func NewNumeric(n int, max uint64) (*Numeric, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidComponents, n)
+	}
+	return &Numeric{n: n, max: max}, nil
+}
+
+// NewAssembly returns the scheme of .NET assembly versions, major.minor.build.revision
+func NewAssembly() *Numeric {
+	return &Numeric{n: 4, max: AssemblyMax}
+}
+
+// Parse parses a version of exactly the number of components of n
+func (n *Numeric) Parse(s string) (Version, error) {
+	i := strings.IndexAny(s, "0123456789")
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q has no version", ErrParsingError, s)
+	}
+	v := NumericVersion{prefix: s[:i], scheme: n}
+	parts := strings.Split(s[i:], ".")
+	if len(parts) != n.n {
+		return nil, fmt.Errorf("%w: %q doesn't have %d components", ErrParsingError, s, n.n)
+	}
+	for _, p := range parts {
+		c, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid component %q in %q", ErrParsingError, p, s)
+		}
+		if n.max != 0 && c > n.max {
+			return nil, fmt.Errorf("%w: component %d of %q is larger than %d", ErrParsingError, c, s, n.max)
+		}
+		v.values = append(v.values, c)
+	}
+	return v, nil
+}
+
+// version asserts that v is a version of n
+func (n *Numeric) version(v Version) NumericVersion {
+	nv, ok := v.(NumericVersion)
+	if !ok || nv.scheme != n {
+		panic(fmt.Sprintf("scheme: %T %v is not a version of a %d component numeric scheme", v, v, n.n))
+	}
+	return nv
+}
+
+// Compare compares a and b component by component
+func (n *Numeric) Compare(a, b Version) int {
+	return slices.Compare(n.version(a).values, n.version(b).values)
+}
+
+// Bump increments the component for level, and resets the following ones. If v is nil, the zero version is bumped
+func (n *Numeric) Bump(v Version, level Level) (Version, error) {
+	rv := NumericVersion{values: make([]uint64, n.n), scheme: n}
+	if v != nil {
+		nv := n.version(v)
+		rv.prefix = nv.prefix
+		copy(rv.values, nv.values)
+	}
+	i := n.n - 1
+	switch level {
+	case Major:
+		i = 0
+	case Minor:
+		i = min(1, n.n-1)
+	}
+	if n.max != 0 && rv.values[i] >= n.max {
+		return nil, fmt.Errorf("%w: component %d of %s is at its maximum %d", ErrCannotBump, i+1, rv.String(), n.max)
+	}
+	rv.values[i]++
+	clear(rv.values[i+1:])
+	return rv, nil
+}
+
+// Format returns the version string of v
+func (n *Numeric) Format(v Version) string {
+	nv := n.version(v)
+	parts := make([]string, len(nv.values))
+	for i, c := range nv.values {
+		parts[i] = strconv.FormatUint(c, 10)
+	}
+	return nv.prefix + strings.Join(parts, ".")
+}
+
+func (v NumericVersion) String() string {
+	return v.scheme.Format(v)
+}
+
+// Prefix returns the non-digit prefix of v, like "v"
+func (v NumericVersion) Prefix() string {
+	return v.prefix
+}
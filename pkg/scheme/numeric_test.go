@@ -0,0 +1,100 @@
+package scheme
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNumeric_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		scheme  *Numeric
+		s       string
+		want    string
+		wantErr error
+	}{
+		{name: "four", scheme: &Numeric{n: 4}, s: "1.2.3.4", want: "1.2.3.4"},
+		{name: "prefix", scheme: &Numeric{n: 4}, s: "v1.2.3.4", want: "v1.2.3.4"},
+		{name: "leading zero", scheme: &Numeric{n: 2}, s: "1.02", want: "1.2"},
+		{name: "too few", scheme: &Numeric{n: 4}, s: "1.2.3", wantErr: ErrParsingError},
+		{name: "too many", scheme: &Numeric{n: 2}, s: "1.2.3", wantErr: ErrParsingError},
+		{name: "suffix", scheme: &Numeric{n: 3}, s: "1.2.3-rc1", wantErr: ErrParsingError},
+		{name: "empty component", scheme: &Numeric{n: 3}, s: "1..3", wantErr: ErrParsingError},
+		{name: "no version", scheme: &Numeric{n: 3}, s: "main", wantErr: ErrParsingError},
+		{name: "assembly", scheme: NewAssembly(), s: "1.2.3.65534", want: "1.2.3.65534"},
+		{name: "assembly too large", scheme: NewAssembly(), s: "1.2.3.65535", wantErr: ErrParsingError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.scheme.Parse(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumeric_Compare(t *testing.T) {
+	n := &Numeric{n: 4}
+	// ascending order
+	versions := []string{"1.2.3.4", "1.2.3.10", "1.2.4.0", "1.10.0.0", "2.0.0.0"}
+	for i := 1; i < len(versions); i++ {
+		a, _ := n.Parse(versions[i-1])
+		b, _ := n.Parse(versions[i])
+		if got := n.Compare(a, b); got != -1 {
+			t.Errorf("Compare(%s, %s) = %d, want -1", versions[i-1], versions[i], got)
+		}
+		if got := n.Compare(b, a); got != 1 {
+			t.Errorf("Compare(%s, %s) = %d, want 1", versions[i], versions[i-1], got)
+		}
+	}
+}
+
+func TestNumeric_Bump(t *testing.T) {
+	tests := []struct {
+		name    string
+		scheme  *Numeric
+		v       string
+		level   Level
+		want    string
+		wantErr error
+	}{
+		{name: "initial", scheme: &Numeric{n: 4}, want: "0.0.0.1"},
+		{name: "patch", scheme: &Numeric{n: 4}, v: "v1.2.3.4", want: "v1.2.3.5"},
+		{name: "minor", scheme: &Numeric{n: 4}, v: "1.2.3.4", level: Minor, want: "1.3.0.0"},
+		{name: "major", scheme: &Numeric{n: 4}, v: "1.2.3.4", level: Major, want: "2.0.0.0"},
+		{name: "two components minor", scheme: &Numeric{n: 2}, v: "1.2", level: Minor, want: "1.3"},
+		{name: "one component", scheme: &Numeric{n: 1}, v: "41", level: Minor, want: "42"},
+		{name: "assembly maximum", scheme: NewAssembly(), v: "1.2.3.65534", wantErr: ErrCannotBump},
+		{name: "assembly minor", scheme: NewAssembly(), v: "1.2.3.65534", level: Minor, want: "1.3.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Version
+			if tt.v != "" {
+				var err error
+				if v, err = tt.scheme.Parse(tt.v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := tt.scheme.Bump(v, tt.level)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Bump() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Bump() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewNumeric(t *testing.T) {
+	if _, err := NewNumeric(0, 0); !errors.Is(err, ErrInvalidComponents) {
+		t.Errorf("NewNumeric() error = %v, wantErr %v", err, ErrInvalidComponents)
+	}
+}
@@ -0,0 +1,79 @@
+package scheme
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownScheme = errors.New("unknown version scheme")
+var ErrParsingError = errors.New("parsing error")
+var ErrCannotBump = errors.New("cannot bump version")
+
+// Level is the part of a version to bump
+type Level int
+
+const (
+	Patch Level = iota
+	Minor
+	Major
+)
+
+func (l Level) String() string {
+	switch l {
+	case Major:
+		return "major"
+	case Minor:
+		return "minor"
+	}
+	return "patch"
+}
+
+// Version is a version parsed by a Scheme. It can only be used with the scheme that created it
+type Version interface {
+	String() string
+}
+
+// Scheme is a versioning scheme, defining how versions are parsed, ordered, bumped and formatted
+type Scheme interface {
+	// Parse parses a version string
+	Parse(s string) (Version, error)
+	// Compare returns -1, 0 or 1 as a sorts before, equal to or after b
+	Compare(a, b Version) int
+	// Bump returns the version following v at the given level. If v is nil, the initial version is returned
+	Bump(v Version, level Level) (Version, error)
+	// Format returns the version string of v
+	Format(v Version) string
+}
+
+// New returns the scheme described by spec, which is the scheme name and any configuration separated by ':'. Supported schemes are "semver", "numeric:N" for N components, "assembly" and "calver:FORMAT", like "calver:YYYY.0M.MICRO"
+func New(spec string) (Scheme, error) {
+	name, config, _ := strings.Cut(spec, ":")
+	switch name {
+	case "semver":
+		return SemVer{}, nil
+	case "numeric":
+		n, err := strconv.Atoi(config)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidComponents, config)
+		}
+		return NewNumeric(n, 0)
+	case "assembly":
+		return NewAssembly(), nil
+	case "calver":
+		return NewCalVer(config)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownScheme, name)
+}
+
+// Max returns the highest of vs according to s, or nil if vs is empty
+func Max(s Scheme, vs []Version) Version {
+	var rv Version
+	for _, v := range vs {
+		if rv == nil || s.Compare(v, rv) > 0 {
+			rv = v
+		}
+	}
+	return rv
+}
@@ -0,0 +1,36 @@
+package scheme
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr error
+	}{
+		{spec: "semver", want: "scheme.SemVer"},
+		{spec: "numeric:4", want: "*scheme.Numeric"},
+		{spec: "assembly", want: "*scheme.Numeric"},
+		{spec: "calver:YYYY.0M.MICRO", want: "*scheme.CalVer"},
+		{spec: "numeric:x", wantErr: ErrInvalidComponents},
+		{spec: "numeric:0", wantErr: ErrInvalidComponents},
+		{spec: "calver:MAJOR", wantErr: ErrInvalidFormat},
+		{spec: "foo", wantErr: ErrUnknownScheme},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := New(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && fmt.Sprintf("%T", got) != tt.want {
+				t.Errorf("New() = %T, want %v", got, tt.want)
+			}
+		})
+	}
+}
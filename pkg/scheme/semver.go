@@ -0,0 +1,85 @@
+package scheme
+
+import (
+	"fmt"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+// SemVer is the semantic versioning scheme. Its versions are semver.SemVer values
+type SemVer struct {
+	// Presep is the prefix separator
+	Presep string
+	// Sufsep is the suffix separator. Default is '-'
+	Sufsep string
+	// Coerce, if not nil, makes Parse accept loose versions, see semver.CoerceWith
+	Coerce *semver.CoerceOptions
+	// Comparator, if not nil, is used by Compare instead of semver.Compare, like the Compare method of a semver.Ranking
+	Comparator semver.Comparator
+}
+
+func (s SemVer) sufsep() string {
+	if s.Sufsep == "" {
+		return "-"
+	}
+	return s.Sufsep
+}
+
+// Zero returns the version 0.0.0, using the separators of s
+func (s SemVer) Zero() semver.SemVer {
+	var sv semver.SemVer
+	sv.Presep(s.Presep)
+	sv.Sufsep(s.sufsep())
+	return sv
+}
+
+// Parse parses a semantic version, with any prefix and suffix
+func (s SemVer) Parse(str string) (Version, error) {
+	if s.Coerce != nil {
+		sv, _, err := semver.CoerceWith(str, *s.Coerce)
+		if err != nil {
+			return nil, err
+		}
+		str = sv.String()
+	}
+	return semver.ParseSeparated(str, s.Presep, s.sufsep())
+}
+
+// Compare compares a and b by semver precedence, or with s.Comparator
+func (s SemVer) Compare(a, b Version) int {
+	if s.Comparator != nil {
+		return s.Comparator(s.version(a), s.version(b))
+	}
+	return semver.Compare(s.version(a), s.version(b))
+}
+
+// Bump increments the major, minor or patch version of v. If v is nil, 0.0.0 is bumped
+func (s SemVer) Bump(v Version, level Level) (Version, error) {
+	sv := s.Zero()
+	if v != nil {
+		sv = s.version(v)
+	}
+	switch level {
+	case Major:
+		sv.IncrementMajor()
+	case Minor:
+		sv.IncrementMinor()
+	default:
+		sv.IncrementPatch()
+	}
+	return sv, nil
+}
+
+// Format returns the version string of v
+func (s SemVer) Format(v Version) string {
+	return s.version(v).String()
+}
+
+// version asserts that v is a semantic version
+func (s SemVer) version(v Version) semver.SemVer {
+	sv, ok := v.(semver.SemVer)
+	if !ok {
+		panic(fmt.Sprintf("scheme: %T %v is not a semantic version", v, v))
+	}
+	return sv
+}
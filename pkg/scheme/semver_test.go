@@ -0,0 +1,77 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+func TestSemVer_Bump(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme SemVer
+		v      string
+		level  Level
+		want   string
+	}{
+		{name: "initial", want: "0.0.1"},
+		{name: "initial major", level: Major, want: "1.0.0"},
+		{name: "patch", v: "v1.2.3-rc.1", want: "v1.2.4-rc.1"},
+		{name: "minor", v: "1.2.3", level: Minor, want: "1.3.0"},
+		{name: "major", v: "1.2.3", level: Major, want: "2.0.0"},
+		{name: "suffix separator", scheme: SemVer{Sufsep: "_"}, v: "1.2.3_main", want: "1.2.4_main"},
+		{name: "coerce", scheme: SemVer{Coerce: &semver.CoerceOptions{}}, v: "v1.2", want: "v1.2.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Version
+			if tt.v != "" {
+				var err error
+				if v, err = tt.scheme.Parse(tt.v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := tt.scheme.Bump(v, tt.level)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := tt.scheme.Format(got); s != tt.want {
+				t.Errorf("Bump() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVer_Compare(t *testing.T) {
+	s := SemVer{}
+	vs := make([]Version, 0, 3)
+	for _, str := range []string{"1.2.3", "v1.10.0", "1.10.0-rc.1"} {
+		v, err := s.Parse(str)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vs = append(vs, v)
+	}
+	if got := Max(s, vs); got.String() != "v1.10.0" {
+		t.Errorf("Max() = %v, want v1.10.0", got)
+	}
+}
+
+func TestSemVer_Comparator(t *testing.T) {
+	r, err := semver.NewRanking("dev", "staging", "rc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := SemVer{Comparator: r.Compare}
+	vs := make([]Version, 0, 3)
+	for _, str := range []string{"1.0.0-staging.2", "1.0.0-rc.1", "1.0.0-dev.4"} {
+		v, err := s.Parse(str)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vs = append(vs, v)
+	}
+	if got := Max(s, vs); got.String() != "1.0.0-rc.1" {
+		t.Errorf("Max() = %v, want 1.0.0-rc.1", got)
+	}
+}
@@ -0,0 +1,121 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Extra is where Coerce puts version components following the patch version
+type Extra int
+
+const (
+	// ExtraBuild puts extra components in the build metadata, so 1.2.3.4 becomes 1.2.3+4
+	ExtraBuild Extra = iota
+	// ExtraPrerelease puts extra components in the pre-release, so 1.2.3.4 becomes 1.2.3-4
+	ExtraPrerelease
+)
+
+// CoerceOptions controls how CoerceWith turns strings into semantic versions
+type CoerceOptions struct {
+	Extra Extra
+}
+
+// Coerce turns a loose version string, like "v1.2", "1", "1.2.3.4" or "release-2024.1", into a semantic version. See CoerceWith
+func Coerce(s string) (SemVer, error) {
+	sv, _, err := CoerceWith(s, CoerceOptions{})
+	return sv, err
+}
+
+// CoerceWith turns a loose version string into a semantic version. Everything before the first digit is the prefix. Missing minor and patch versions are set to 0, leading zeros are stripped from numeric components, and components after the patch version are moved to the build metadata or pre-release, as given by opts. Anything following the numeric components is kept as the suffix. The returned strings describe each change made
+func CoerceWith(s string, opts CoerceOptions) (SemVer, []string, error) {
+	start := strings.IndexAny(s, "0123456789")
+	if start < 0 {
+		return SemVer{}, nil, fmt.Errorf("%w: no version number in %q", ErrParsingError, s)
+	}
+	var changes []string
+	var comps []string
+	i := start
+	for {
+		j := i
+		for j < len(s) && isDigit(s[j]) {
+			j++
+		}
+		comps = append(comps, s[i:j])
+		i = j
+		if i+1 >= len(s) || s[i] != '.' || !isDigit(s[i+1]) {
+			break
+		}
+		i++
+	}
+	rest := s[i:]
+
+	names := []string{"major", "minor", "patch"}
+	for n, c := range comps {
+		if trimmed := trimZeros(c); trimmed != c {
+			name := fmt.Sprintf("component %d", n+1)
+			if n < len(names) {
+				name = names[n] + " version"
+			}
+			changes = append(changes, fmt.Sprintf("stripped leading zeros from %s %s", name, c))
+			comps[n] = trimmed
+		}
+	}
+	for len(comps) < 3 {
+		changes = append(changes, fmt.Sprintf("set missing %s version to 0", names[len(comps)]))
+		comps = append(comps, "0")
+	}
+
+	suffix := rest
+	if extra := strings.Join(comps[3:], "."); extra != "" {
+		var pre, build string
+		switch {
+		case strings.HasPrefix(rest, "-"):
+			pre, build, _ = strings.Cut(rest[1:], "+")
+		case strings.HasPrefix(rest, "+"):
+			build = rest[1:]
+		case rest != "":
+			pre = rest
+		}
+		if opts.Extra == ExtraPrerelease {
+			pre = join(extra, pre)
+			changes = append(changes, fmt.Sprintf("moved %s to the pre-release", extra))
+		} else {
+			build = join(extra, build)
+			changes = append(changes, fmt.Sprintf("moved %s to the build metadata", extra))
+		}
+		suffix = ""
+		if pre != "" {
+			suffix = "-" + pre
+		}
+		if build != "" {
+			suffix += "+" + build
+		}
+	}
+
+	sv, err := Parse(s[:start] + strings.Join(comps[:3], ".") + suffix)
+	if err != nil {
+		return SemVer{}, nil, err
+	}
+	return sv, changes, nil
+}
+
+// trimZeros strips leading zeros from a number, leaving a single 0 for zero
+func trimZeros(s string) string {
+	t := strings.TrimLeft(s, "0")
+	if t == "" {
+		return "0"
+	}
+	return t
+}
+
+// join joins two dot-separated lists of identifiers, either of which may be empty
+func join(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + "." + b
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,119 @@
+package semver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCoerceWith(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		opts        CoerceOptions
+		want        string
+		wantChanges []string
+		wantErr     error
+	}{
+		{
+			name: "valid",
+			s:    "v1.2.3-rc.1",
+			want: "v1.2.3-rc.1",
+		},
+		{
+			name:        "missing patch",
+			s:           "v1.2",
+			want:        "v1.2.0",
+			wantChanges: []string{"set missing patch version to 0"},
+		},
+		{
+			name:        "major only",
+			s:           "1",
+			want:        "1.0.0",
+			wantChanges: []string{"set missing minor version to 0", "set missing patch version to 0"},
+		},
+		{
+			name:        "extra component",
+			s:           "1.2.3.4",
+			want:        "1.2.3+4",
+			wantChanges: []string{"moved 4 to the build metadata"},
+		},
+		{
+			name:        "extra components in pre-release",
+			s:           "1.2.3.4.5-beta+exp",
+			opts:        CoerceOptions{Extra: ExtraPrerelease},
+			want:        "1.2.3-4.5.beta+exp",
+			wantChanges: []string{"moved 4.5 to the pre-release"},
+		},
+		{
+			name:        "extra component before build metadata",
+			s:           "1.2.3.4+exp",
+			want:        "1.2.3+4.exp",
+			wantChanges: []string{"moved 4 to the build metadata"},
+		},
+		{
+			name:        "prefix",
+			s:           "release-2024.1",
+			want:        "release-2024.1.0",
+			wantChanges: []string{"set missing patch version to 0"},
+		},
+		{
+			name:        "leading zeros",
+			s:           "1.02.3",
+			want:        "1.2.3",
+			wantChanges: []string{"stripped leading zeros from minor version 02"},
+		},
+		{
+			name:        "zero",
+			s:           "00.1",
+			want:        "0.1.0",
+			wantChanges: []string{"stripped leading zeros from major version 00", "set missing patch version to 0"},
+		},
+		{
+			name: "trailing dot",
+			s:    "1.2.3.",
+			want: "1.2.3.",
+		},
+		{
+			name:    "no number",
+			s:       "main",
+			wantErr: ErrParsingError,
+		},
+		{
+			name:    "too large",
+			s:       "99999999999",
+			wantErr: ErrParsingError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changes, err := CoerceWith(tt.s, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CoerceWith() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("CoerceWith() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(changes, tt.wantChanges) {
+				t.Errorf("CoerceWith() changes = %q, want %q", changes, tt.wantChanges)
+			}
+		})
+	}
+}
+
+func TestCoerce(t *testing.T) {
+	got, err := Coerce("v1.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Major() != 1 || got.Minor() != 2 || got.Patch() != 0 {
+		t.Errorf("Coerce() = %v, want v1.2.0", got)
+	}
+	if p, _ := got.PreSuffix(); p != "v" {
+		t.Errorf("Coerce() prefix = %q, want v", p)
+	}
+}
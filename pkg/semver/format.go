@@ -0,0 +1,67 @@
+package semver
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Format implements fmt.Formatter. %s and %v print the complete version, and with a precision of 1 to 3, the short form of the prefix and that many components, so %.2v prints "v1.2". %d prints only the version numbers, so %d prints "1.2.3" and %.1d prints "1". %q prints the complete version quoted, and %#v the Go syntax of s. A width pads with spaces, on the right with the '-' flag
+func (s SemVer) Format(f fmt.State, verb rune) {
+	var str string
+	switch verb {
+	case 's', 'v':
+		if verb == 'v' && f.Flag('#') {
+			str = s.goString()
+			break
+		}
+		str = s.String()
+		if n, ok := f.Precision(); ok {
+			str = s.components(n)
+			if s.prefix != "" {
+				str = s.prefix + s.presep + str
+			}
+		}
+	case 'd':
+		str = s.components(3)
+		if n, ok := f.Precision(); ok {
+			str = s.components(n)
+		}
+	case 'q':
+		str = strconv.Quote(s.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(semver.SemVer=%s)", verb, s.String())
+		return
+	}
+	w, ok := f.Width()
+	if n := utf8.RuneCountInString(str); ok && n < w {
+		fill := strings.Repeat(" ", w-n)
+		if f.Flag('-') {
+			str += fill
+		} else {
+			str = fill + str
+		}
+	}
+	io.WriteString(f, str)
+}
+
+// components returns the first n of the major, minor and patch versions, dot separated. n is at least 1 and at most 3
+func (s SemVer) components(n int) string {
+	n = min(max(n, 1), 3)
+	parts := []uint{s.major, s.minor, s.patch}[:n]
+	b := strings.Builder{}
+	for i, p := range parts {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.FormatUint(uint64(p), 10))
+	}
+	return b.String()
+}
+
+// goString returns the Go syntax of s, like the default %#v of a struct
+func (s SemVer) goString() string {
+	return fmt.Sprintf("semver.SemVer{major:%d, minor:%d, patch:%d, prefix:%q, presep:%q, suffix:%q, sufsep:%q}", s.major, s.minor, s.patch, s.prefix, s.presep, s.suffix, s.sufsep)
+}
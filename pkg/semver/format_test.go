@@ -0,0 +1,45 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSemVer_Format(t *testing.T) {
+	sv, err := ParseSeparated("v1.2.3-rc.1+b.5", "", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, err := ParseSeparated("app@1.2.3", "@", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		format string
+		sv     SemVer
+		want   string
+	}{
+		{format: "%s", sv: sv, want: "v1.2.3-rc.1+b.5"},
+		{format: "%v", sv: sv, want: "v1.2.3-rc.1+b.5"},
+		{format: "%.1v", sv: sv, want: "v1"},
+		{format: "%.2s", sv: sv, want: "v1.2"},
+		{format: "%.3v", sv: sv, want: "v1.2.3"},
+		{format: "%.2v", sv: New(1, 2, 3), want: "1.2"},
+		{format: "%.2v", sv: app, want: "app@1.2"},
+		{format: "%d", sv: sv, want: "1.2.3"},
+		{format: "%.1d", sv: sv, want: "1"},
+		{format: "%.9d", sv: sv, want: "1.2.3"},
+		{format: "%q", sv: sv, want: `"v1.2.3-rc.1+b.5"`},
+		{format: "%8d|", sv: sv, want: "   1.2.3|"},
+		{format: "%-8d|", sv: sv, want: "1.2.3   |"},
+		{format: "%#v", sv: New(1, 2, 3), want: `semver.SemVer{major:1, minor:2, patch:3, prefix:"", presep:"", suffix:"", sufsep:"-"}`},
+		{format: "%x", sv: New(1, 2, 3), want: "%!x(semver.SemVer=1.2.3)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, tt.sv); got != tt.want {
+				t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
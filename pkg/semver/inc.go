@@ -0,0 +1,155 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidIncrement = errors.New("invalid increment")
+
+// ReleaseType is the kind of increment done by Inc, as in npm's semver.inc
+type ReleaseType string
+
+const (
+	ReleaseMajor      ReleaseType = "major"
+	ReleaseMinor      ReleaseType = "minor"
+	ReleasePatch      ReleaseType = "patch"
+	ReleasePremajor   ReleaseType = "premajor"
+	ReleasePreminor   ReleaseType = "preminor"
+	ReleasePrepatch   ReleaseType = "prepatch"
+	ReleasePrerelease ReleaseType = "prerelease"
+)
+
+// ReleaseTypes lists all release types
+var ReleaseTypes = []ReleaseType{ReleaseMajor, ReleaseMinor, ReleasePatch, ReleasePremajor, ReleasePreminor, ReleasePrepatch, ReleasePrerelease}
+
+// ParseReleaseType returns the release type named s
+func ParseReleaseType(s string) (ReleaseType, error) {
+	for _, r := range ReleaseTypes {
+		if string(r) == s {
+			return r, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown release type %q", ErrInvalidIncrement, s)
+}
+
+// IsPre reports whether r makes a pre-release
+func (r ReleaseType) IsPre() bool {
+	return strings.HasPrefix(string(r), "pre")
+}
+
+// IncOptions are the pre-release options of Inc
+type IncOptions struct {
+	// Identifier is the pre-release identifier, like "rc". If empty, the pre-release is only a number
+	Identifier string
+	// Base is the first number of a new pre-release, 0 or 1
+	Base uint
+	// NoBase leaves out the number of a new pre-release with an Identifier, so 1.2.3 becomes 1.2.4-rc instead of 1.2.4-rc.0
+	NoBase bool
+}
+
+// Inc returns sv incremented as npm's semver.inc does. Unlike the Increment methods, major, minor and patch release a pending pre-release of the same line, so 1.2.3-rc.1 becomes 1.2.3 with a patch release, and 2.0.0-rc.1 becomes 2.0.0 with a major release. premajor, preminor and prepatch increment the version and start a pre-release, and prerelease increments the last number of an existing pre-release, or starts a pre-release of the next patch version. Build metadata is dropped, the prefix is kept
+func Inc(sv SemVer, release ReleaseType, opts IncOptions) (SemVer, error) {
+	if opts.Base > 1 {
+		return SemVer{}, fmt.Errorf("%w: pre-release base must be 0 or 1, not %d", ErrInvalidIncrement, opts.Base)
+	}
+	if opts.NoBase && opts.Identifier == "" {
+		return SemVer{}, fmt.Errorf("%w: a pre-release without a number needs an identifier", ErrInvalidIncrement)
+	}
+	// without a separator, the suffix keeps the '-' before the pre-release
+	sep := ""
+	if sv.sufsep == "" {
+		sep = "-"
+	}
+	pre := sv.Prerelease()
+	var ids []string
+	if pre != "" {
+		ids = strings.Split(pre, ".")
+	}
+
+	var err error
+	switch release {
+	case ReleaseMajor:
+		if len(ids) == 0 || sv.minor != 0 || sv.patch != 0 {
+			sv.major, sv.minor, sv.patch = sv.major+1, 0, 0
+		}
+		ids = nil
+	case ReleaseMinor:
+		if len(ids) == 0 || sv.patch != 0 {
+			sv.minor, sv.patch = sv.minor+1, 0
+		}
+		ids = nil
+	case ReleasePatch:
+		if len(ids) == 0 {
+			sv.patch++
+		}
+		ids = nil
+	case ReleasePremajor:
+		sv.major, sv.minor, sv.patch = sv.major+1, 0, 0
+		ids, err = incPre(nil, opts)
+	case ReleasePreminor:
+		sv.minor, sv.patch = sv.minor+1, 0
+		ids, err = incPre(nil, opts)
+	case ReleasePrepatch:
+		sv.patch++
+		ids, err = incPre(nil, opts)
+	case ReleasePrerelease:
+		if len(ids) == 0 {
+			sv.patch++
+		}
+		ids, err = incPre(ids, opts)
+	default:
+		return SemVer{}, fmt.Errorf("%w: unknown release type %q", ErrInvalidIncrement, release)
+	}
+	if err != nil {
+		return SemVer{}, err
+	}
+	sv.suffix = ""
+	if len(ids) > 0 {
+		sv.suffix = sep + strings.Join(ids, ".")
+	}
+	return sv, nil
+}
+
+// incPre increments the pre-release identifiers ids, as npm's semver.inc does for "pre"
+func incPre(ids []string, opts IncOptions) ([]string, error) {
+	base := strconv.FormatUint(uint64(opts.Base), 10)
+	if len(ids) == 0 {
+		ids = []string{base}
+	} else {
+		incremented := false
+		for i := len(ids) - 1; i >= 0; i-- {
+			if n, err := strconv.ParseUint(ids[i], 10, 64); err == nil {
+				ids[i] = strconv.FormatUint(n+1, 10)
+				incremented = true
+				break
+			}
+		}
+		if !incremented {
+			if opts.Identifier == strings.Join(ids, ".") && opts.NoBase {
+				return nil, fmt.Errorf("%w: pre-release %s already exists", ErrInvalidIncrement, opts.Identifier)
+			}
+			ids = append(ids, base)
+		}
+	}
+	if opts.Identifier == "" {
+		return ids, nil
+	}
+	fresh := []string{opts.Identifier, base}
+	if opts.NoBase {
+		fresh = fresh[:1]
+	}
+	// an existing pre-release of the same identifier keeps counting, unless it isn't followed by a number
+	if ids[0] == opts.Identifier {
+		if len(ids) < 2 {
+			return fresh, nil
+		}
+		if _, err := strconv.ParseUint(ids[1], 10, 64); err != nil {
+			return fresh, nil
+		}
+		return ids, nil
+	}
+	return fresh, nil
+}
@@ -0,0 +1,91 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInc(t *testing.T) {
+	// cases from npm's semver increment fixtures
+	tests := []struct {
+		v       string
+		release ReleaseType
+		opts    IncOptions
+		want    string
+		wantErr error
+	}{
+		{v: "1.2.3", release: ReleaseMajor, want: "2.0.0"},
+		{v: "1.2.3", release: ReleaseMinor, want: "1.3.0"},
+		{v: "1.2.3", release: ReleasePatch, want: "1.2.4"},
+		{v: "1.2.3-tag", release: ReleaseMajor, want: "2.0.0"},
+		{v: "1.2.3", release: "fake", wantErr: ErrInvalidIncrement},
+		{v: "1.2.0-0", release: ReleasePatch, want: "1.2.0"},
+		{v: "1.2.3-4", release: ReleaseMajor, want: "2.0.0"},
+		{v: "1.2.3-4", release: ReleaseMinor, want: "1.3.0"},
+		{v: "1.2.3-4", release: ReleasePatch, want: "1.2.3"},
+		{v: "1.2.3-alpha.0.beta", release: ReleaseMajor, want: "2.0.0"},
+		{v: "1.2.3-alpha.0.beta", release: ReleaseMinor, want: "1.3.0"},
+		{v: "1.2.3-alpha.0.beta", release: ReleasePatch, want: "1.2.3"},
+		{v: "1.2.4", release: ReleasePrerelease, want: "1.2.5-0"},
+		{v: "1.2.3-0", release: ReleasePrerelease, want: "1.2.3-1"},
+		{v: "1.2.3-alpha.0", release: ReleasePrerelease, want: "1.2.3-alpha.1"},
+		{v: "1.2.3-alpha.0.beta", release: ReleasePrerelease, want: "1.2.3-alpha.1.beta"},
+		{v: "1.2.3-alpha.10.0.beta", release: ReleasePrerelease, want: "1.2.3-alpha.10.1.beta"},
+		{v: "1.2.3-alpha.10.beta.0", release: ReleasePrerelease, want: "1.2.3-alpha.10.beta.1"},
+		{v: "1.2.3-alpha.9.beta", release: ReleasePrerelease, want: "1.2.3-alpha.10.beta"},
+		{v: "1.2.3-dev.bar", release: ReleasePrerelease, want: "1.2.3-dev.bar.0"},
+		{v: "1.2.0", release: ReleasePrepatch, want: "1.2.1-0"},
+		{v: "1.2.0-1", release: ReleasePrepatch, want: "1.2.1-0"},
+		{v: "1.2.0", release: ReleasePreminor, want: "1.3.0-0"},
+		{v: "1.2.3-1", release: ReleasePreminor, want: "1.3.0-0"},
+		{v: "1.2.0", release: ReleasePremajor, want: "2.0.0-0"},
+		{v: "1.2.3-1", release: ReleasePremajor, want: "2.0.0-0"},
+		{v: "1.2.0-1", release: ReleaseMinor, want: "1.2.0"},
+		{v: "1.0.0-1", release: ReleaseMajor, want: "1.0.0"},
+		{v: "1.2.3", release: ReleaseMajor, opts: IncOptions{Identifier: "dev"}, want: "2.0.0"},
+		{v: "1.2.3-tag", release: ReleaseMajor, opts: IncOptions{Identifier: "dev"}, want: "2.0.0"},
+		{v: "1.2.4", release: ReleasePrerelease, opts: IncOptions{Identifier: "dev"}, want: "1.2.5-dev.0"},
+		{v: "1.2.3-0", release: ReleasePrerelease, opts: IncOptions{Identifier: "dev"}, want: "1.2.3-dev.0"},
+		{v: "1.2.3-alpha.0", release: ReleasePrerelease, opts: IncOptions{Identifier: "dev"}, want: "1.2.3-dev.0"},
+		{v: "1.2.3-alpha.0", release: ReleasePrerelease, opts: IncOptions{Identifier: "alpha"}, want: "1.2.3-alpha.1"},
+		{v: "1.2.3-alpha.0.beta", release: ReleasePrerelease, opts: IncOptions{Identifier: "alpha"}, want: "1.2.3-alpha.1.beta"},
+		{v: "1.2.3-dev.bar", release: ReleasePrerelease, opts: IncOptions{Identifier: "dev"}, want: "1.2.3-dev.0"},
+		{v: "1.2.0", release: ReleasePrepatch, opts: IncOptions{Identifier: "dev"}, want: "1.2.1-dev.0"},
+		{v: "1.2.3-1", release: ReleasePremajor, opts: IncOptions{Identifier: "dev"}, want: "2.0.0-dev.0"},
+		{v: "1.2.0", release: ReleasePreminor, opts: IncOptions{Identifier: "dev", Base: 1}, want: "1.3.0-dev.1"},
+		{v: "1.2.3", release: ReleasePrerelease, opts: IncOptions{Identifier: "dev", NoBase: true}, want: "1.2.4-dev"},
+		{v: "1.2.3-dev", release: ReleasePrerelease, opts: IncOptions{Identifier: "dev", NoBase: true}, wantErr: ErrInvalidIncrement},
+		{v: "1.2.3", release: ReleasePrerelease, opts: IncOptions{NoBase: true}, wantErr: ErrInvalidIncrement},
+		{v: "1.2.3", release: ReleasePrerelease, opts: IncOptions{Base: 2}, wantErr: ErrInvalidIncrement},
+		{v: "v1.2.3-rc.1+build.5", release: ReleasePrerelease, want: "v1.2.3-rc.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.v+" "+string(tt.release)+" "+tt.opts.Identifier, func(t *testing.T) {
+			sv, err := ParseSeparated(tt.v, "", "-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Inc(sv, tt.release, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Inc() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Inc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInc_noSeparator(t *testing.T) {
+	sv, err := Parse("1.2.3-rc.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Inc(sv, ReleasePrerelease, IncOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "1.2.3-rc.2" {
+		t.Errorf("Inc() = %v, want 1.2.3-rc.2", got)
+	}
+}
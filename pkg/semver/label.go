@@ -2,10 +2,13 @@ package semver
 
 import (
 	"cmp"
-	"strconv"
+	"errors"
+	"fmt"
 	"strings"
 )
 
+var ErrInvalidPrerelease = errors.New("invalid pre-release")
+
 // pre is the entire pre-release version pre. It is a dot-separated list of identifiers
 type pre string
 
@@ -23,66 +26,57 @@ func (id identifiers) String() string {
 	return strings.Join(sl, ".")
 }
 
-func max[T cmp.Ordered](a, b T) T {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-func min[T cmp.Ordered](a, b T) T {
-	if a < b {
-		return a
-	}
-	return b
-}
-
-// idMax  returns the largest (most significant) of two identifiers
-func idMax(a, b identifier) identifier {
+// compareIdentifier compares two identifiers by semver precedence. Numeric identifiers are compared as numbers of any size, and have lower precedence than alphanumeric ones. Alphanumeric identifiers in rank are ordered by it, and have higher precedence than other alphanumeric identifiers
+func compareIdentifier(a, b identifier, rank map[identifier]int) int {
 	// is one or both pre numeric?
-	ia, na := a.numeric()
-	ib, nb := b.numeric()
+	na := a.numeric()
+	nb := b.numeric()
 
 	switch {
 	case na && nb:
-		return identifier(strconv.Itoa(max(ia, ib)))
+		// compare as numbers of any size: longer is larger, then lexically
+		ta, tb := strings.TrimLeft(string(a), "0"), strings.TrimLeft(string(b), "0")
+		if c := cmp.Compare(len(ta), len(tb)); c != 0 {
+			return c
+		}
+		return strings.Compare(ta, tb)
 	case na:
-		return b
+		return -1
 	case nb:
-		return a
+		return 1
+	}
+	ra, rankedA := rank[a]
+	rb, rankedB := rank[b]
+	switch {
+	case rankedA && rankedB:
+		return cmp.Compare(ra, rb)
+	case rankedA:
+		return 1
+	case rankedB:
+		return -1
 	}
-	return max(a, b)
+	return strings.Compare(string(a), string(b))
 }
 
-// idsMax returns the largest (most significant) of two sets of identifiers
-func idsMax(a, b identifiers) identifiers {
+// compareIdentifiers compares two sets of identifiers by semver precedence, see compareIdentifier
+func compareIdentifiers(a, b identifiers, rank map[identifier]int) int {
 	// nothing is always higher priority than something. It's called a 'pre-release label' after all
-	if len(a) == 0 || len(b) == 0 {
-		return identifiers{}
-	}
-
-	sm := a // smaller (element count)
-	lg := b // larger (element count
-
-	if len(a) > len(b) {
-		sm = b
-		lg = a
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
 	}
-
 	// compare each element of the smaller set to the equivalent in the larger set
-	for i := range sm {
-		if a[i] == b[i] {
-			continue
-		}
-		if idMax(a[i], b[i]) == a[i] {
-			return a
-		}
-		if idMax(a[i], b[i]) == b[i] {
-			return b
+	for i := range min(len(a), len(b)) {
+		if c := compareIdentifier(a[i], b[i], rank); c != 0 {
+			return c
 		}
 	}
-	// a and b are equivalent. Return the longest
-	return lg
+	// a and b are equivalent. The longest wins
+	return cmp.Compare(len(a), len(b))
 }
 
 func (t pre) components() identifiers {
@@ -99,19 +93,110 @@ func (t pre) components() identifiers {
 	return ids
 }
 
-func (i identifier) numeric() (int, bool) {
-	n, err := strconv.Atoi(string(i))
-	return n, err == nil
+// numeric reports whether i consists of digits only
+func (i identifier) numeric() bool {
+	return i != "" && strings.Trim(string(i), "0123456789") == ""
+}
+
+// valid reports whether i is a valid semver identifier: alphanumerics and hyphens, and no leading zeros if numeric
+func (i identifier) valid() bool {
+	if i == "" || strings.Trim(string(i), "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-") != "" {
+		return false
+	}
+	return !i.numeric() || i == "0" || i[0] != '0'
+}
+
+// compare compares p and q by semver precedence
+func (p pre) compare(q pre) int {
+	return compareIdentifiers(p.components(), q.components(), nil)
 }
 
-// MaxSlice returns the max (meaning most significant, higher precedence) of p and q, according to Semver
+// Max returns the max (meaning most significant, higher precedence) of p and q, according to Semver. If they have equal precedence, p is returned
 func (p pre) Max(q pre) string {
-	a := p.components()
-	b := q.components()
-	m := idsMax(a, b)
-	return m.String()
+	if p.compare(q) >= 0 {
+		return string(p)
+	}
+	return string(q)
 }
 
+// MaxLabel returns the pre-release label of a and b with the highest precedence. If they have equal precedence, a is returned
 func MaxLabel(a, b string) string {
 	return pre(a).Max(pre(b))
 }
+
+// Prerelease is a valid semver pre-release label, a dot separated list of identifiers. The zero value is the empty label of a release
+type Prerelease struct {
+	ids identifiers
+}
+
+// ParsePrerelease parses a pre-release label like "rc.3.hotfix.1", without the leading '-'. Identifiers must be non-empty, contain only alphanumerics and hyphens, and numeric identifiers can't have leading zeros
+func ParsePrerelease(s string) (Prerelease, error) {
+	ids := pre(s).components()
+	for _, id := range ids {
+		if !id.valid() {
+			return Prerelease{}, fmt.Errorf("%w: identifier %q of %q", ErrInvalidPrerelease, id, s)
+		}
+	}
+	return Prerelease{ids: ids}, nil
+}
+
+// String returns the label, or the empty string for a release
+func (p Prerelease) String() string {
+	return p.ids.String()
+}
+
+// Identifiers returns the identifiers of p
+func (p Prerelease) Identifiers() []string {
+	rv := make([]string, len(p.ids))
+	for i, id := range p.ids {
+		rv[i] = string(id)
+	}
+	return rv
+}
+
+// IsNumeric reports whether identifier i of p is numeric. It is false if there is no identifier i
+func (p Prerelease) IsNumeric(i int) bool {
+	return i >= 0 && i < len(p.ids) && p.ids[i].numeric()
+}
+
+// Compare returns -1, 0 or 1 if p has lower, equal or higher precedence than q. The empty label of a release has the highest precedence
+func (p Prerelease) Compare(q Prerelease) int {
+	return compareIdentifiers(p.ids, q.ids, nil)
+}
+
+// Append returns p with the identifiers ids added, or an error if any of them is invalid
+func (p Prerelease) Append(ids ...string) (Prerelease, error) {
+	rv := Prerelease{ids: make(identifiers, len(p.ids), len(p.ids)+len(ids))}
+	copy(rv.ids, p.ids)
+	for _, id := range ids {
+		if !identifier(id).valid() {
+			return Prerelease{}, fmt.Errorf("%w: identifier %q", ErrInvalidPrerelease, id)
+		}
+		rv.ids = append(rv.ids, identifier(id))
+	}
+	return rv, nil
+}
+
+// BumpLast returns p with its last identifier incremented, so "rc.3.hotfix.1" becomes "rc.3.hotfix.2". It is an error if the last identifier isn't numeric
+func (p Prerelease) BumpLast() (Prerelease, error) {
+	if !p.IsNumeric(len(p.ids) - 1) {
+		return Prerelease{}, fmt.Errorf("%w: last identifier of %q is not numeric", ErrInvalidPrerelease, p)
+	}
+	rv := Prerelease{ids: make(identifiers, len(p.ids))}
+	copy(rv.ids, p.ids)
+	rv.ids[len(rv.ids)-1] = increment(rv.ids[len(rv.ids)-1])
+	return rv, nil
+}
+
+// increment adds one to the numeric identifier i, which can be of any size
+func increment(i identifier) identifier {
+	digits := []byte(i)
+	for k := len(digits) - 1; k >= 0; k-- {
+		if digits[k] != '9' {
+			digits[k]++
+			return identifier(digits)
+		}
+		digits[k] = '0'
+	}
+	return identifier("1" + string(digits))
+}
@@ -1,6 +1,10 @@
 package semver
 
-import "testing"
+import (
+	"errors"
+	"slices"
+	"testing"
+)
 
 func Test_pre_Max(t *testing.T) {
 	type args struct {
@@ -84,6 +88,22 @@ func Test_pre_Max(t *testing.T) {
 			},
 			want: "1.2.4.a.b.1.2",
 		},
+		{
+			name: "equal numbers, the next identifiers decide",
+			p:    "01.a",
+			args: args{
+				q: "1.b",
+			},
+			want: "1.b",
+		},
+		{
+			name: "equal numbers of any size",
+			p:    "99999999999999999999",
+			args: args{
+				q: "099999999999999999999",
+			},
+			want: "99999999999999999999",
+		},
 		{
 			name: "nothing is always more prevalent than something",
 			p:    "",
@@ -101,3 +121,123 @@ func Test_pre_Max(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxLabel_equal(t *testing.T) {
+	if got := MaxLabel("01", "1"); got != "01" {
+		t.Errorf("MaxLabel(01, 1) = %q, want 01", got)
+	}
+	if got := MaxLabel("1", "01"); got != "1" {
+		t.Errorf("MaxLabel(1, 01) = %q, want 1", got)
+	}
+}
+
+func TestParsePrerelease(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			s:    "",
+			want: []string{},
+		},
+		{
+			name: "mixed",
+			s:    "rc.3.hotfix.1",
+			want: []string{"rc", "3", "hotfix", "1"},
+		},
+		{
+			name: "hyphens and zero",
+			s:    "x-y.0.-",
+			want: []string{"x-y", "0", "-"},
+		},
+		{
+			name:    "empty identifier",
+			s:       "rc..1",
+			wantErr: true,
+		},
+		{
+			name:    "leading zero",
+			s:       "rc.01",
+			wantErr: true,
+		},
+		{
+			name:    "invalid character",
+			s:       "rc_1",
+			wantErr: true,
+		},
+		{
+			name:    "build metadata",
+			s:       "rc.1+b",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePrerelease(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePrerelease() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidPrerelease) {
+					t.Errorf("ParsePrerelease() error = %v, want ErrInvalidPrerelease", err)
+				}
+				return
+			}
+			if !slices.Equal(got.Identifiers(), tt.want) {
+				t.Errorf("Identifiers() = %q, want %q", got.Identifiers(), tt.want)
+			}
+			if got.String() != tt.s {
+				t.Errorf("String() = %q, want %q", got.String(), tt.s)
+			}
+		})
+	}
+}
+
+func TestPrerelease(t *testing.T) {
+	p, err := ParsePrerelease("rc.3.hotfix.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.IsNumeric(1) || p.IsNumeric(2) || p.IsNumeric(4) || p.IsNumeric(-1) {
+		t.Errorf("IsNumeric() is wrong for %q", p)
+	}
+	bumped, err := p.BumpLast()
+	if err != nil || bumped.String() != "rc.3.hotfix.2" {
+		t.Errorf("BumpLast() = %q, %v, want rc.3.hotfix.2", bumped, err)
+	}
+	if p.String() != "rc.3.hotfix.1" {
+		t.Errorf("BumpLast() modified its receiver to %q", p)
+	}
+	if p.Compare(bumped) != -1 || bumped.Compare(p) != 1 || p.Compare(p) != 0 {
+		t.Errorf("Compare() of %q and %q is wrong", p, bumped)
+	}
+	if (Prerelease{}).Compare(p) != 1 {
+		t.Error("Compare() of a release is not higher than a pre-release")
+	}
+	nines, _ := ParsePrerelease("rc.99999999999999999999")
+	if got, _ := nines.BumpLast(); got.String() != "rc.100000000000000000000" {
+		t.Errorf("BumpLast() = %q, want rc.100000000000000000000", got)
+	}
+	appended, err := p.Append("2")
+	if err != nil || appended.String() != "rc.3.hotfix.1.2" {
+		t.Errorf("Append() = %q, %v, want rc.3.hotfix.1.2", appended, err)
+	}
+	if _, err := appended.BumpLast(); err != nil {
+		t.Error(err)
+	}
+	if _, err := p.Append("b", "02"); !errors.Is(err, ErrInvalidPrerelease) {
+		t.Errorf("Append() of an invalid identifier error = %v, want ErrInvalidPrerelease", err)
+	}
+	if _, err := (Prerelease{}).Append("rc"); err != nil {
+		t.Error(err)
+	}
+	for _, s := range []string{"", "rc"} {
+		q, _ := ParsePrerelease(s)
+		if _, err := q.BumpLast(); !errors.Is(err, ErrInvalidPrerelease) {
+			t.Errorf("BumpLast() of %q error = %v, want ErrInvalidPrerelease", s, err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+// The regular expression implementation of Parse, which the scanner must match
+var (
+	versionRE = regexp.MustCompile(SEMVERRE)
+	prefixRE  = regexp.MustCompile(SEMVERRE_PRE_RE)
+	suffixRE  = regexp.MustCompile(SEMVERRE_SUF_RE)
+)
+
+func regexpParse(s string) (SemVer, error) {
+	var sv SemVer
+	matches := versionRE.FindStringSubmatch(s)
+	if len(matches) != 4 {
+		return SemVer{}, ErrParsingError
+	}
+	rv := make([]uint, 3)
+	for i, v := range matches[1:] {
+		p, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return SemVer{}, ErrParsingError
+		}
+		rv[i] = uint(p)
+	}
+	sv.major, sv.minor, sv.patch = rv[0], rv[1], rv[2]
+	matches = prefixRE.FindStringSubmatch(s)
+	if len(matches) < 5 {
+		return SemVer{}, ErrParsingError
+	}
+	sv.prefix = matches[1]
+	matches = suffixRE.FindStringSubmatch(s)
+	if len(matches) < 5 {
+		return SemVer{}, ErrParsingError
+	}
+	sv.suffix = matches[len(matches)-1]
+	return sv, nil
+}
+
+var parseInputs = []string{
+	"1.2.3",
+	"v1.2.3",
+	"v1.2.3-rc.1+build.5",
+	"release-2024.10.17_main",
+	"1.2.x1.22.333-beta",
+	"first line\nv1.2.3-dev\nnext line",
+	"0001.002.3",
+	"2147483647.0.0",
+	"2147483648.0.0",
+	"99999999999999999999999.1.1",
+	"1.2",
+	"",
+	"...1..2.3.4.5",
+	"v\xff1.2.3\xfe",
+}
+
+func TestParse_regexp(t *testing.T) {
+	for _, s := range parseInputs {
+		t.Run(fmt.Sprintf("%q", s), func(t *testing.T) {
+			checkEquivalent(t, s)
+		})
+	}
+}
+
+func checkEquivalent(t *testing.T, s string) {
+	t.Helper()
+	want, wantErr := regexpParse(s)
+	got, err := Parse(s)
+	if err != wantErr || got != want {
+		t.Errorf("Parse(%q) = %#v, %v, regexp = %#v, %v", s, got, err, want, wantErr)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range parseInputs {
+		f.Add(s)
+	}
+	f.Fuzz(checkEquivalent)
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range parseInputs {
+			Parse(s)
+		}
+	}
+}
+
+func BenchmarkParse_regexp(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range parseInputs {
+			regexpParse(s)
+		}
+	}
+}
+
+// BenchmarkParse_tags parses the tags of a large repository
+func BenchmarkParse_tags(b *testing.B) {
+	tags := make([]string, 50000)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("v%d.%d.%d-rc.%d", i/1000, i/100%10, i%100, i%7)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, tag := range tags {
+			if _, err := Parse(tag); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestParseSeparated_roundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		pre, suf   string
+		wantPrefix string
+		wantSuffix string
+		wantString string
+		wantErr    error
+	}{
+		{name: "separator in prefix", s: "my-app-1.2.3-rc.1", pre: "-", suf: "-", wantPrefix: "my-app", wantSuffix: "rc.1", wantString: "my-app-1.2.3-rc.1"},
+		{name: "component", s: "svc-api@1.2.3", pre: "@", suf: "-", wantPrefix: "svc-api", wantString: "svc-api@1.2.3"},
+		{name: "multi-char", s: "release--1.2.3--rc.1", pre: "--", suf: "--", wantPrefix: "release", wantSuffix: "rc.1", wantString: "release--1.2.3--rc.1"},
+		{name: "prefix shorter than separator", s: "v1.2.3", pre: "--", suf: "-", wantPrefix: "v", wantString: "v--1.2.3"},
+		{name: "suffix shorter than separator", s: "1.2.3-x", pre: "", suf: "---", wantSuffix: "-x", wantString: "1.2.3----x"},
+		{name: "only separators", s: "-1.2.3-", pre: "-", suf: "-", wantString: "1.2.3"},
+		{name: "build metadata", s: "v2.0.0+incompatible", pre: "", suf: "-", wantPrefix: "v", wantSuffix: "+incompatible", wantString: "v2.0.0+incompatible"},
+		{name: "plus separator", s: "1.2.3++b", pre: "", suf: "+", wantSuffix: "+b", wantString: "1.2.3++b"},
+		{name: "digit separator", s: "1.2.3", pre: "1", suf: "-", wantErr: ErrInvalidSeparator},
+		{name: "newline separator", s: "1.2.3", pre: "", suf: "\n", wantErr: ErrInvalidSeparator},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSeparated(tt.s, tt.pre, tt.suf)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseSeparated() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if p, s := got.PreSuffix(); p != tt.wantPrefix || s != tt.wantSuffix {
+				t.Errorf("ParseSeparated() prefix, suffix = %q, %q, want %q, %q", p, s, tt.wantPrefix, tt.wantSuffix)
+			}
+			if got.String() != tt.wantString {
+				t.Errorf("String() = %q, want %q", got.String(), tt.wantString)
+			}
+			checkRoundTrip(t, got, tt.pre, tt.suf)
+		})
+	}
+}
+
+// checkRoundTrip checks that sv parses back from its string
+func checkRoundTrip(t *testing.T, sv SemVer, pre, suf string) {
+	t.Helper()
+	again, err := ParseSeparated(sv.String(), pre, suf)
+	if err != nil {
+		t.Fatalf("ParseSeparated(%q, %q, %q): %v", sv.String(), pre, suf, err)
+	}
+	if again != sv {
+		t.Fatalf("ParseSeparated(%q, %q, %q) = %#v, want %#v", sv.String(), pre, suf, again, sv)
+	}
+	if again.String() != sv.String() {
+		t.Fatalf("String() = %q, want %q", again.String(), sv.String())
+	}
+}
+
+func FuzzParseSeparated(f *testing.F) {
+	for _, s := range parseInputs {
+		f.Add(s, "", "-")
+	}
+	f.Add("my-app-1.2.3-rc.1", "-", "-")
+	f.Add("release--1.2.3--rc.1", "--", "--")
+	f.Add("v1.2.3", "--", "-")
+	f.Add("1.2.3-x", "", "---")
+	f.Add("1.2.3++b", "", "+")
+	f.Fuzz(func(t *testing.T, s, pre, suf string) {
+		sv, err := ParseSeparated(s, pre, suf)
+		if err != nil {
+			return
+		}
+		checkRoundTrip(t, sv, pre, suf)
+	})
+}
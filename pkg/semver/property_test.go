@@ -0,0 +1,286 @@
+package semver
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// specValid are the valid versions from the semver.org test vectors that fit in 32 bit numbers
+var specValid = []string{
+	"0.0.4",
+	"1.2.3",
+	"10.20.30",
+	"1.1.2-prerelease+meta",
+	"1.1.2+meta",
+	"1.1.2+meta-valid",
+	"1.0.0-alpha",
+	"1.0.0-beta",
+	"1.0.0-alpha.beta",
+	"1.0.0-alpha.beta.1",
+	"1.0.0-alpha.1",
+	"1.0.0-alpha0.valid",
+	"1.0.0-alpha.0valid",
+	"1.0.0-alpha-a.b-c-somethinglong+build.1-aef.1-its-okay",
+	"1.0.0-rc.1+build.1",
+	"2.0.0-rc.1+build.123",
+	"1.2.3-beta",
+	"10.2.3-DEV-SNAPSHOT",
+	"1.2.3-SNAPSHOT-123",
+	"1.0.0",
+	"2.0.0",
+	"1.1.7",
+	"2.0.0+build.1848",
+	"2.0.1-alpha.1227",
+	"1.0.0-alpha+beta",
+	"1.2.3----RC-SNAPSHOT.12.9.1--.12+788",
+	"1.2.3----R-S.12.9.1--.12+meta",
+	"1.2.3----RC-SNAPSHOT.12.9.1--.12",
+	"1.0.0+0.build.1-rc.10000aaa-kk-0.1",
+	"1.0.0-0A.is.legal",
+}
+
+// specInvalid are invalid versions from the semver.org test vectors. Parse is lenient and accepts some of them, they are used to seed the fuzzers
+var specInvalid = []string{
+	"1", "1.2", "1.2.3-0123", "1.2.3-0123.0123", "1.1.2+.123", "+invalid", "-invalid", "-invalid+invalid",
+	"-invalid.01", "alpha", "alpha.beta", "alpha.beta.1", "alpha.1", "alpha+beta", "alpha_beta", "alpha.",
+	"alpha..", "beta", "1.0.0-alpha_beta", "-alpha.", "1.0.0-alpha..", "1.0.0-alpha..1", "1.0.0-alpha...1",
+	"1.0.0-alpha....1", "1.0.0-alpha.....1", "1.0.0-alpha......1", "1.0.0-alpha.......1", "01.1.1", "1.01.1",
+	"1.1.01", "1.2", "1.2.3.DEV", "1.2-SNAPSHOT", "1.2.31.2.3----RC-SNAPSHOT.12.09.1--..12+788", "1.2-RC-SNAPSHOT",
+	"-1.0.3-gamma+b7718", "+justmeta", "9.8.7+meta+meta", "9.8.7-whatever+meta+meta",
+	"99999999999999999999999.999999999.99999999999----RC-SNAPSHOT.12.09.1--------------------------------..12",
+}
+
+// specOrder is the precedence example of the semver spec, in ascending order
+var specOrder = []string{
+	"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0",
+	"2.0.0", "2.1.0", "2.1.1",
+}
+
+func parseSpec(t testing.TB, s string) SemVer {
+	t.Helper()
+	sv, err := ParseSeparated(s, "", "-")
+	if err != nil {
+		t.Fatalf("ParseSeparated(%q): %v", s, err)
+	}
+	return sv
+}
+
+func TestSpecConformance(t *testing.T) {
+	for _, s := range specValid {
+		t.Run(s, func(t *testing.T) {
+			if got := parseSpec(t, s).String(); got != s {
+				t.Errorf("String() = %q, want %q", got, s)
+			}
+		})
+	}
+	for i := range specOrder {
+		for j := range specOrder {
+			a, b := parseSpec(t, specOrder[i]), parseSpec(t, specOrder[j])
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := Compare(a, b); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+	// build metadata is ignored
+	for _, pair := range [][2]string{{"1.0.0+20130313144700", "1.0.0"}, {"1.0.0-beta+exp.sha.5114f85", "1.0.0-beta"}, {"1.0.0-alpha+001", "1.0.0-alpha+002"}} {
+		if got := Compare(parseSpec(t, pair[0]), parseSpec(t, pair[1])); got != 0 {
+			t.Errorf("Compare(%s, %s) = %d, want 0", pair[0], pair[1], got)
+		}
+	}
+}
+
+// randomVersions returns n random valid versions, drawn from a small space so equal and close versions are common
+func randomVersions(r *rand.Rand, n int) []SemVer {
+	ids := []string{"alpha", "beta", "rc", "0", "1", "2", "11", "-", "a-b", "x1", "1a", "99999999999999999999"}
+	builds := []string{"", "b1", "exp.sha"}
+	vs := make([]SemVer, n)
+	for i := range vs {
+		s := fmt.Sprintf("%d.%d.%d", r.IntN(3), r.IntN(3), r.IntN(3))
+		var pre []string
+		for range r.IntN(4) {
+			pre = append(pre, ids[r.IntN(len(ids))])
+		}
+		if len(pre) > 0 {
+			s += "-" + strings.Join(pre, ".")
+		}
+		if b := builds[r.IntN(len(builds))]; b != "" {
+			s += "+" + b
+		}
+		sv, err := ParseSeparated(s, "", "-")
+		if err != nil {
+			panic(err)
+		}
+		vs[i] = sv
+	}
+	return vs
+}
+
+func TestCompare_properties(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	vs := randomVersions(r, 200)
+	for _, a := range vs {
+		if got := Compare(a, a); got != 0 {
+			t.Fatalf("Compare(%s, %s) = %d, want 0", a, a, got)
+		}
+		for _, b := range vs {
+			ab, ba := Compare(a, b), Compare(b, a)
+			if ab != -ba {
+				t.Fatalf("Compare(%s, %s) = %d, but Compare(%s, %s) = %d", a, b, ab, b, a, ba)
+			}
+			if ab == 0 && (a.Version() != b.Version() || a.Prerelease() != b.Prerelease()) {
+				t.Fatalf("Compare(%s, %s) = 0 for versions of different precedence", a, b)
+			}
+			if ab < 0 && Max(a, b) != b {
+				t.Fatalf("Max(%s, %s) = %s, want %s", a, b, Max(a, b), b)
+			}
+			for _, c := range vs {
+				if ab <= 0 && Compare(b, c) <= 0 && Compare(a, c) > 0 {
+					t.Fatalf("%s <= %s <= %s, but Compare(%s, %s) > 0", a, b, c, a, c)
+				}
+			}
+		}
+	}
+}
+
+func TestSort_properties(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 100 {
+		vs := randomVersions(r, r.IntN(20))
+		sorted := Sorted(vs)
+		if !slices.IsSortedFunc(sorted, Compare) {
+			t.Fatalf("Sorted(%v) = %v is not sorted", vs, sorted)
+		}
+		if len(vs) == 0 {
+			continue
+		}
+		if max := MaxSlice(vs); Compare(max, sorted[len(sorted)-1]) != 0 {
+			t.Fatalf("MaxSlice(%v) = %s, want %s", vs, max, sorted[len(sorted)-1])
+		}
+		if min := MinSlice(vs); Compare(min, sorted[0]) != 0 {
+			t.Fatalf("MinSlice(%v) = %s, want %s", vs, min, sorted[0])
+		}
+		if n, want := NewCollection(vs...).Len(), len(slices.CompactFunc(sorted, Equal)); n != want {
+			t.Fatalf("NewCollection(%v) has %d versions, want %d", vs, n, want)
+		}
+	}
+}
+
+// specComparePre is a reference implementation of the semver pre-release precedence rules
+func specComparePre(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, y := as[i], bs[i]
+		if x == y {
+			continue
+		}
+		xn, yn := strings.Trim(x, "0123456789") == "", strings.Trim(y, "0123456789") == ""
+		switch {
+		case xn && yn:
+			if len(x) != len(y) {
+				return sign(len(x) - len(y))
+			}
+			return strings.Compare(x, y)
+		case xn:
+			return -1
+		case yn:
+			return 1
+		}
+		return strings.Compare(x, y)
+	}
+	return sign(len(as) - len(bs))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// validLabel reports whether s is a valid pre-release label
+func validLabel(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, id := range strings.Split(s, ".") {
+		if id == "" || strings.Trim(id, "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ-") != "" {
+			return false
+		}
+		if len(id) > 1 && id[0] == '0' && strings.Trim(id, "0123456789") == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func FuzzMaxLabel(f *testing.F) {
+	for i := range specOrder {
+		for j := range specOrder {
+			f.Add(parseSpec(f, specOrder[i]).Prerelease(), parseSpec(f, specOrder[j]).Prerelease())
+		}
+	}
+	f.Add("alpha.99999999999999999999", "alpha.-")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		if !validLabel(a) || !validLabel(b) {
+			t.Skip()
+		}
+		got := MaxLabel(a, b)
+		if got != a && got != b {
+			t.Fatalf("MaxLabel(%q, %q) = %q, which is neither", a, b, got)
+		}
+		if rev := MaxLabel(b, a); rev != got {
+			t.Fatalf("MaxLabel(%q, %q) = %q, but MaxLabel(%q, %q) = %q", a, b, got, b, a, rev)
+		}
+		want := a
+		if specComparePre(a, b) < 0 {
+			want = b
+		}
+		if got != want {
+			t.Fatalf("MaxLabel(%q, %q) = %q, want %q", a, b, got, want)
+		}
+	})
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	for _, s := range append(slices.Clone(specValid), specInvalid...) {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		sv, err := ParseSeparated(s, "", "-")
+		if err != nil {
+			return
+		}
+		again, err := ParseSeparated(sv.String(), "", "-")
+		if err != nil {
+			t.Fatalf("ParseSeparated(%q) = %#v, but its string %q doesn't parse: %v", s, sv, sv.String(), err)
+		}
+		if again != sv {
+			t.Fatalf("ParseSeparated(%q) = %#v, but its string %q parses as %#v", s, sv, sv.String(), again)
+		}
+		if again.String() != sv.String() {
+			t.Fatalf("String() of %q is not stable: %q, %q", s, sv.String(), again.String())
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package semver
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrInvalidRanking = errors.New("invalid label ranking")
+
+// Ranking is a declared order of named pre-release identifiers, like the channels of a promotion chain where dev < staging < rc, which the semver rules would sort lexically. Ranked identifiers are ordered by rank, and have higher precedence than other alphanumeric identifiers. Everything else is compared by the semver rules. The zero value ranks nothing, and compares like Compare
+type Ranking struct {
+	rank map[identifier]int
+}
+
+// NewRanking returns the ranking of labels, lowest first. Labels must be valid, non-numeric identifiers, and can only be given once
+func NewRanking(labels ...string) (Ranking, error) {
+	r := Ranking{rank: make(map[identifier]int, len(labels))}
+	for i, l := range labels {
+		id := identifier(l)
+		if !id.valid() || id.numeric() {
+			return Ranking{}, fmt.Errorf("%w: %q is not an alphanumeric identifier", ErrInvalidRanking, l)
+		}
+		if _, ok := r.rank[id]; ok {
+			return Ranking{}, fmt.Errorf("%w: %q is ranked twice", ErrInvalidRanking, l)
+		}
+		r.rank[id] = i
+	}
+	return r, nil
+}
+
+// ParseRanking reads a ranking from a config file, lowest first. Labels are separated by commas or whitespace, and '#' starts a comment to the end of the line, like
+//
+//	# promotion chain
+//	dev, staging, rc
+func ParseRanking(r io.Reader) (Ranking, error) {
+	var labels []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		labels = append(labels, strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\r'
+		})...)
+	}
+	if err := sc.Err(); err != nil {
+		return Ranking{}, err
+	}
+	return NewRanking(labels...)
+}
+
+// Labels returns the ranked labels, lowest first
+func (r Ranking) Labels() []string {
+	rv := make([]string, len(r.rank))
+	for id, i := range r.rank {
+		rv[i] = string(id)
+	}
+	return rv
+}
+
+// Compare compares a and b like Compare, but orders ranked pre-release identifiers by their rank. It is a Comparator
+func (r Ranking) Compare(a, b SemVer) int {
+	return compare(a, b, r.rank)
+}
+
+// CompareLabels compares the pre-release labels a and b, ordering ranked identifiers by their rank
+func (r Ranking) CompareLabels(a, b string) int {
+	return compareIdentifiers(pre(a).components(), pre(b).components(), r.rank)
+}
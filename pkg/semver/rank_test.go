@@ -0,0 +1,164 @@
+package semver
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRanking_Compare(t *testing.T) {
+	r, err := NewRanking("dev", "staging", "rc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{
+			name: "declared rank",
+			a:    "1.0.0-dev.5",
+			b:    "1.0.0-staging.1",
+			want: -1,
+		},
+		{
+			name: "declared rank, not lexical",
+			a:    "1.0.0-staging.9",
+			b:    "1.0.0-rc.1",
+			want: -1,
+		},
+		{
+			name: "same channel falls back to the spec",
+			a:    "1.0.0-rc.10",
+			b:    "1.0.0-rc.9",
+			want: 1,
+		},
+		{
+			name: "ranked over unranked",
+			a:    "1.0.0-dev",
+			b:    "1.0.0-zeta",
+			want: 1,
+		},
+		{
+			name: "unranked by the spec",
+			a:    "1.0.0-alpha",
+			b:    "1.0.0-beta",
+			want: -1,
+		},
+		{
+			name: "numeric below ranked",
+			a:    "1.0.0-1",
+			b:    "1.0.0-dev",
+			want: -1,
+		},
+		{
+			name: "release over pre-release",
+			a:    "1.0.0",
+			b:    "1.0.0-rc.1",
+			want: 1,
+		},
+		{
+			name: "version first",
+			a:    "1.0.1-dev",
+			b:    "1.0.0-rc.1",
+			want: 1,
+		},
+		{
+			name: "ranked identifier after the first",
+			a:    "1.0.0-x.dev",
+			b:    "1.0.0-x.rc",
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := parseSpec(t, tt.a), parseSpec(t, tt.b)
+			if got := r.Compare(a, b); got != tt.want {
+				t.Errorf("Compare() = %v, want %v", got, tt.want)
+			}
+			if got := r.Compare(b, a); got != -tt.want {
+				t.Errorf("Compare() reversed = %v, want %v", got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestRanking_MaxSlice(t *testing.T) {
+	r, err := NewRanking("dev", "staging", "rc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs := mustParseAll(t, "1.0.0-staging.3", "1.0.0-rc.1", "1.0.0-dev.7")
+	if got := MaxSlice(vs); got.String() != "1.0.0-staging.3" {
+		t.Errorf("MaxSlice() = %v, want 1.0.0-staging.3", got)
+	}
+	if got := MaxSlice(vs, r.Compare); got.String() != "1.0.0-rc.1" {
+		t.Errorf("MaxSlice(ranked) = %v, want 1.0.0-rc.1", got)
+	}
+	if got := r.CompareLabels("staging.3", "dev.7"); got != 1 {
+		t.Errorf("CompareLabels() = %v, want 1", got)
+	}
+	if got := (Ranking{}).Compare(vs[0], vs[1]); got != Compare(vs[0], vs[1]) {
+		t.Errorf("zero Ranking Compare() = %v, want %v", got, Compare(vs[0], vs[1]))
+	}
+}
+
+func TestParseRanking(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "one per line",
+			config: "# promotion chain\ndev\nstaging # QA\n\nrc\n",
+			want:   []string{"dev", "staging", "rc"},
+		},
+		{
+			name:   "comma separated",
+			config: "dev, staging,rc\r\n",
+			want:   []string{"dev", "staging", "rc"},
+		},
+		{
+			name:   "empty",
+			config: "",
+			want:   []string{},
+		},
+		{
+			name:    "duplicate",
+			config:  "dev staging dev",
+			wantErr: true,
+		},
+		{
+			name:    "numeric",
+			config:  "dev 1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid identifier",
+			config:  "dev.1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRanking(strings.NewReader(tt.config))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRanking() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidRanking) {
+					t.Errorf("ParseRanking() error = %v, want ErrInvalidRanking", err)
+				}
+				return
+			}
+			if !slices.Equal(got.Labels(), tt.want) {
+				t.Errorf("Labels() = %q, want %q", got.Labels(), tt.want)
+			}
+		})
+	}
+}
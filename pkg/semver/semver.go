@@ -1,20 +1,23 @@
 package semver
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
-	"regexp"
-	"sort"
-	"strconv"
+	"math"
+	"slices"
 	"strings"
 )
 
 // SEMVERRE is the semantic versioning regexp
 const SEMVERRE = `(\d+)\.(\d+)\.(\d+)`
-const SEMVERRE_PRE_RE = `(.*)` + SEMVERRE
+
+// SEMVERRE_PRE_RE matches a prefix and a version. The prefix is as short as possible, so it ends at the first version in the string, not the last
+const SEMVERRE_PRE_RE = `(.*?)` + SEMVERRE
 const SEMVERRE_SUF_RE = SEMVERRE + `(.*)`
 
 var ErrParsingError = errors.New("parsing error")
+var ErrInvalidSeparator = errors.New("invalid separator")
 
 // SemVer is a struct representing a semantic version
 type SemVer struct {
@@ -33,41 +36,163 @@ type ByVersionDescending []SemVer
 func (a ByVersionDescending) Len() int      { return len(a) }
 func (a ByVersionDescending) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a ByVersionDescending) Less(i, j int) bool {
-	return Max(a[i], a[j]) == a[i]
+	return CompareDesc(a[i], a[j]) < 0
+}
+
+// Comparator compares versions like Compare, returning -1, 0 or 1 if a has lower, equal or higher precedence than b
+type Comparator func(a, b SemVer) int
+
+// MaxSlice returns the highest version in a list, or the zero version if it is empty. v is not modified. Versions are compared with Compare, or with cmp if given
+func MaxSlice(v []SemVer, cmp ...Comparator) SemVer {
+	c := comparator(cmp)
+	return fold(slices.Values(v), func(a, b SemVer) SemVer {
+		if c(a, b) >= 0 {
+			return a
+		}
+		return b
+	})
 }
 
-// MaxSlice returns the highest version in a list
-func MaxSlice(v []SemVer) SemVer {
-	if len(v) == 0 {
-		return SemVer{}
+// comparator returns the first of cmp, or Compare if there is none
+func comparator(cmp []Comparator) Comparator {
+	if len(cmp) > 0 && cmp[0] != nil {
+		return cmp[0]
 	}
-	sort.Sort(ByVersionDescending(v))
-	return v[0]
+	return Compare
 }
 
+// Max returns the highest of a and b. If they have equal precedence, a is returned
 func Max(a, b SemVer) SemVer {
-	if a.major > b.major || a.minor > b.minor || a.patch > b.patch {
-		return a
-	}
-	if a.major < b.major || a.minor < b.minor || a.patch < b.patch {
-		return b
-	}
-	if MaxLabel(a.suffix, b.suffix) == a.suffix {
+	if Compare(a, b) >= 0 {
 		return a
 	}
 	return b
 }
 
+// Compare returns -1 if a has lower precedence than b, 1 if it has higher precedence, and 0 if they are of equal precedence. Build metadata is ignored, as per semver
+func Compare(a, b SemVer) int {
+	return compare(a, b, nil)
+}
+
+// compare compares a and b like Compare, ordering pre-release identifiers in rank by it
+func compare(a, b SemVer, rank map[identifier]int) int {
+	for _, c := range [][2]uint{{a.major, b.major}, {a.minor, b.minor}, {a.patch, b.patch}} {
+		if c[0] != c[1] {
+			return cmp.Compare(c[0], c[1])
+		}
+	}
+	return compareIdentifiers(pre(a.Prerelease()).components(), pre(b.Prerelease()).components(), rank)
+}
+
 // Version returns the semantic version, without any prefixes or suffixes
 func (s SemVer) Version() string {
 	return fmt.Sprintf("%d.%d.%d", s.major, s.minor, s.patch)
 }
 
+// Major returns the major version of s
+func (s SemVer) Major() uint {
+	return s.major
+}
+
+// Minor returns the minor version of s
+func (s SemVer) Minor() uint {
+	return s.minor
+}
+
+// Patch returns the patch version of s
+func (s SemVer) Patch() uint {
+	return s.patch
+}
+
 // PreSuffix returns the prefix and suffix of s
 func (s SemVer) PreSuffix() (string, string) {
 	return s.prefix, s.suffix
 }
 
+// Prerelease returns the pre-release part of the suffix, that is everything before any '+'. Without a suffix separator, as from Parse, the suffix keeps the '-' before the pre-release, which is not part of it
+func (s SemVer) Prerelease() string {
+	pre, _, _ := strings.Cut(s.suffix, "+")
+	if s.sufsep == "" {
+		pre = strings.TrimPrefix(pre, "-")
+	}
+	return pre
+}
+
+// Build returns the build metadata part of the suffix, that is everything after the first '+'
+func (s SemVer) Build() string {
+	_, build, _ := strings.Cut(s.suffix, "+")
+	return build
+}
+
+// PrefixString returns the prefix of s, without the separator
+func (s SemVer) PrefixString() string {
+	return s.prefix
+}
+
+// SuffixString returns the suffix of s, that is the pre-release and build metadata, without the separator
+func (s SemVer) SuffixString() string {
+	return s.suffix
+}
+
+// PrefixSeparator returns the prefix separator of s
+func (s SemVer) PrefixSeparator() string {
+	return s.presep
+}
+
+// SuffixSeparator returns the suffix separator of s
+func (s SemVer) SuffixSeparator() string {
+	return s.sufsep
+}
+
+// WithPrefix returns a copy of s with the prefix set to prefix
+func (s SemVer) WithPrefix(prefix string) SemVer {
+	s.prefix = prefix
+	return s
+}
+
+// WithPrerelease returns a copy of s with the pre-release set to pre, keeping any build metadata. An empty pre removes the pre-release
+func (s SemVer) WithPrerelease(pre string) SemVer {
+	// without a separator, the suffix keeps the '-' before the pre-release
+	if s.sufsep == "" && pre != "" {
+		pre = "-" + pre
+	}
+	s.suffix = joinSuffix(pre, s.Build())
+	return s
+}
+
+// WithBuild returns a copy of s with the build metadata set to build, keeping any pre-release. An empty build removes the build metadata
+func (s SemVer) WithBuild(build string) SemVer {
+	pre, _, _ := strings.Cut(s.suffix, "+")
+	s.suffix = joinSuffix(pre, build)
+	return s
+}
+
+// joinSuffix returns the suffix of a pre-release and build metadata
+func joinSuffix(pre, build string) string {
+	if build == "" {
+		return pre
+	}
+	return pre + "+" + build
+}
+
+// BumpMajor returns a copy of s with the major version incremented and minor and patch set to zero
+func (s SemVer) BumpMajor() SemVer {
+	s.IncrementMajor()
+	return s
+}
+
+// BumpMinor returns a copy of s with the minor version incremented and patch set to zero
+func (s SemVer) BumpMinor() SemVer {
+	s.IncrementMinor()
+	return s
+}
+
+// BumpPatch returns a copy of s with the patch version incremented
+func (s SemVer) BumpPatch() SemVer {
+	s.IncrementPatch()
+	return s
+}
+
 // String returns the complete string semantic version
 func (s SemVer) String() string {
 	v := strings.Builder{}
@@ -77,7 +202,10 @@ func (s SemVer) String() string {
 	}
 	v.WriteString(s.Version())
 	if s.suffix != "" {
-		v.WriteString(s.sufsep)
+		// a suffix of only build metadata is written as is, so "+incompatible" doesn't become "-+incompatible". It still parses the same, unless the separator is a prefix of it
+		if !strings.HasPrefix(s.suffix, "+") || strings.HasPrefix(s.suffix, s.sufsep) {
+			v.WriteString(s.sufsep)
+		}
 		v.WriteString(s.suffix)
 	}
 	return v.String()
@@ -100,42 +228,57 @@ func (s *SemVer) IncrementPatch() {
 	s.patch++
 }
 
+// Suffix sets the suffix of s. See WithPrerelease and WithBuild for a copy instead
 func (s *SemVer) Suffix(suffix string) {
 	s.suffix = suffix
 }
 
+// Prefix sets the prefix of s. See WithPrefix for a copy instead
 func (s *SemVer) Prefix(prefix string) {
 	s.prefix = prefix
 }
 
+// Presep sets the prefix separator of s
 func (s *SemVer) Presep(pre string) {
 	s.presep = pre
 }
 
+// Sufsep sets the suffix separator of s
 func (s *SemVer) Sufsep(suf string) {
 	s.sufsep = suf
 }
 
+// New returns the version major.minor.patch, with '-' as the suffix separator
+func New(major, minor, patch uint) SemVer {
+	return SemVer{major: major, minor: minor, patch: patch, sufsep: "-"}
+}
+
+// MustParse is like Parse, but panics if s can't be parsed
+func MustParse(s string) SemVer {
+	sv, err := Parse(s)
+	if err != nil {
+		panic(fmt.Sprintf("semver: Parse(%q): %v", s, err))
+	}
+	return sv
+}
+
 // Parse returns a new SemVer, or an error is a parsing error occurs
 func Parse(s string) (SemVer, error) {
-	var sv SemVer
-	var err error
-	sv.major, sv.minor, sv.patch, err = version(s)
+	m, err := scan(s)
 	if err != nil {
 		return SemVer{}, err
 	}
-	sv.prefix, err = prefix(s)
-	if err != nil {
-		return SemVer{}, err
+	if m.overflow {
+		return SemVer{}, ErrParsingError
 	}
-	sv.suffix, err = suffix(s)
-	if err != nil {
-		return SemVer{}, err
-	}
-	return sv, nil
+	return SemVer{major: m.major, minor: m.minor, patch: m.patch, prefix: m.prefix(s), suffix: m.suffix(s)}, nil
 }
 
+// ParseSeparated parses s like Parse, removing prefixSeparator from the end of the prefix and suffixSeparator from the start of the suffix, if they are there. The separators are kept, and used by String, so for any version returned, ParseSeparated(sv.String(), prefixSeparator, suffixSeparator) returns the same version. Separators can be any length, but can't contain digits or newlines
 func ParseSeparated(s, prefixSeparator, suffixSeparator string) (SemVer, error) {
+	if strings.ContainsAny(prefixSeparator+suffixSeparator, "0123456789\n") {
+		return SemVer{}, fmt.Errorf("%w: %q, %q", ErrInvalidSeparator, prefixSeparator, suffixSeparator)
+	}
 	sv, err := Parse(s)
 	if err != nil {
 		return SemVer{}, err
@@ -144,76 +287,108 @@ func ParseSeparated(s, prefixSeparator, suffixSeparator string) (SemVer, error)
 	return sv, nil
 }
 
-// Separators will handle using specific separator characters to separate prefixes, version strings and suffixes
+// separators sets the separators of s, and removes them from the prefix and suffix
 func (s *SemVer) separators(pre, suf string) {
 	s.presep = pre
 	s.sufsep = suf
+	s.prefix = strings.TrimSuffix(s.prefix, pre)
+	s.suffix = strings.TrimPrefix(s.suffix, suf)
+}
 
-	func() {
-		if pre != "" && len(s.prefix) < 1 {
-			return
+// match is the position and value of the version number in a string
+type match struct {
+	// start and end are the offsets of the major.minor.patch version number
+	start, end          int
+	major, minor, patch uint
+	// overflow is true if a number doesn't fit in an int32, which is a parsing error
+	overflow bool
+}
+
+// scan finds the first major.minor.patch version number in s in a single pass, without allocating. It finds the same match as SEMVERRE, SEMVERRE_PRE_RE and SEMVERRE_SUF_RE
+func scan(s string) (match, error) {
+	for i := 0; i < len(s); {
+		if !isDigit(s[i]) {
+			i++
+			continue
 		}
-		// handle if there's no prefix, only a prefix character. Which would be weird, but hey (ex: prefix separator: "-", version string: "-1.2.3")
-		if s.presep == s.prefix {
-			s.prefix = ""
-			return
+		// a match can only start at the beginning of a run of digits
+		if m, ok := scanAt(s, i); ok {
+			return m, nil
 		}
-		if s.prefix[len(s.prefix)-len(s.presep):] != s.presep {
-			return
+		for i < len(s) && isDigit(s[i]) {
+			i++
 		}
-		s.prefix = s.prefix[0 : len(s.prefix)-len(s.presep)]
-	}()
+	}
+	return match{}, ErrParsingError
+}
 
-	func() {
-		if suf != "" && len(s.suffix) < 1 {
-			return
+// scanAt matches three dot separated numbers at s[i:]
+func scanAt(s string, i int) (match, bool) {
+	m := match{start: i}
+	var nums [3]uint64
+	for k := range nums {
+		if k > 0 {
+			if i >= len(s) || s[i] != '.' {
+				return match{}, false
+			}
+			i++
 		}
-		if s.sufsep == s.suffix {
-			s.suffix = ""
-			return
+		begin := i
+		for ; i < len(s) && isDigit(s[i]); i++ {
+			if nums[k] <= math.MaxInt32 {
+				nums[k] = nums[k]*10 + uint64(s[i]-'0')
+			}
 		}
-		if s.suffix[:len(s.sufsep)] != s.sufsep {
-			return
+		if i == begin {
+			return match{}, false
 		}
-		s.suffix = s.suffix[len(s.sufsep):]
-	}()
+		m.overflow = m.overflow || nums[k] > math.MaxInt32
+	}
+	m.end = i
+	m.major, m.minor, m.patch = uint(nums[0]), uint(nums[1]), uint(nums[2])
+	return m, true
+}
 
+// prefix returns the text before the version number on the same line, as SEMVERRE_PRE_RE does
+func (m match) prefix(s string) string {
+	return s[strings.LastIndexByte(s[:m.start], '\n')+1 : m.start]
+}
+
+// suffix returns the text after the version number up to the end of the line, as SEMVERRE_SUF_RE does
+func (m match) suffix(s string) string {
+	rest := s[m.end:]
+	if i := strings.IndexByte(rest, '\n'); i >= 0 {
+		return rest[:i]
+	}
+	return rest
 }
 
 // version extracts major,minor and patch versions from a semantic version string
 func version(s string) (uint, uint, uint, error) {
-	re := regexp.MustCompile(SEMVERRE)
-	matches := re.FindStringSubmatch(s)
-	if len(matches) != 4 {
-		return 0, 0, 0, ErrParsingError
+	m, err := scan(s)
+	if err != nil {
+		return 0, 0, 0, err
 	}
-	rv := make([]uint, 3)
-	for i, v := range matches[1:] {
-		p, err := strconv.ParseInt(v, 10, 32)
-		if err != nil {
-			return 0, 0, 0, ErrParsingError
-		}
-		rv[i] = uint(p)
+	if m.overflow {
+		return 0, 0, 0, ErrParsingError
 	}
-	return rv[0], rv[1], rv[2], nil
+	return m.major, m.minor, m.patch, nil
 }
 
 // prefix extracts a given prefix from a semver string
 func prefix(s string) (string, error) {
-	re := regexp.MustCompile(SEMVERRE_PRE_RE)
-	matches := re.FindStringSubmatch(s)
-	if len(matches) < 5 {
-		return "", ErrParsingError
+	m, err := scan(s)
+	if err != nil {
+		return "", err
 	}
-	return matches[1], nil
+	return m.prefix(s), nil
 }
 
 // suffix returns any suffix from a semver string
 func suffix(s string) (string, error) {
-	re := regexp.MustCompile(SEMVERRE_SUF_RE)
-	matches := re.FindStringSubmatch(s)
-	if len(matches) < 5 {
-		return "", ErrParsingError
+	m, err := scan(s)
+	if err != nil {
+		return "", err
 	}
-	return matches[len(matches)-1], nil
+	return m.suffix(s), nil
 }
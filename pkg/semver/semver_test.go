@@ -164,6 +164,15 @@ func Test_prefix(t *testing.T) {
 			want:    "foo-",
 			wantErr: false,
 		},
+		{
+			// the prefix ends at the first version, not at the last one, like 1.0.20261019070438
+			name: "more than one version in string",
+			args: args{
+				s: "v1.3.0-rc.1.0.20261019070438-23bef6da5b84",
+			},
+			want:    "v",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -644,6 +653,22 @@ func TestMax1(t *testing.T) {
 			},
 			want: SemVer{patch: 2},
 		},
+		{
+			name: "major beats higher patch",
+			args: args{
+				a: SemVer{major: 1, patch: 5},
+				b: SemVer{major: 2},
+			},
+			want: SemVer{major: 2},
+		},
+		{
+			name: "minor beats higher patch, reversed",
+			args: args{
+				a: SemVer{minor: 2},
+				b: SemVer{minor: 1, patch: 9},
+			},
+			want: SemVer{minor: 2},
+		},
 		{
 			name: "suffix",
 			args: args{
@@ -669,3 +694,284 @@ func TestMax1(t *testing.T) {
 		})
 	}
 }
+
+func TestSemVer_PrereleaseBuild(t *testing.T) {
+	tests := []struct {
+		name      string
+		suffix    string
+		sufsep    string
+		wantPre   string
+		wantBuild string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:      "without separator, the '-' is not part of the pre-release",
+			suffix:    "-rc.1+build.5",
+			wantPre:   "rc.1",
+			wantBuild: "build.5",
+		},
+		{
+			name:    "pre-release only",
+			suffix:  "rc.1",
+			wantPre: "rc.1",
+		},
+		{
+			name:      "build only",
+			suffix:    "+build.5",
+			wantBuild: "build.5",
+		},
+		{
+			name:      "both",
+			suffix:    "rc.1+build.5+more",
+			wantPre:   "rc.1",
+			wantBuild: "build.5+more",
+		},
+		{
+			name:      "with separator",
+			suffix:    "-x+b",
+			sufsep:    "-",
+			wantPre:   "-x",
+			wantBuild: "b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SemVer{suffix: tt.suffix, sufsep: tt.sufsep}
+			if got := s.Prerelease(); got != tt.wantPre {
+				t.Errorf("Prerelease() = %v, want %v", got, tt.wantPre)
+			}
+			if got := s.Build(); got != tt.wantBuild {
+				t.Errorf("Build() = %v, want %v", got, tt.wantBuild)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{
+			name: "equal",
+			a:    "1.2.3",
+			b:    "1.2.3",
+			want: 0,
+		},
+		{
+			name: "major over patch",
+			a:    "1.0.5",
+			b:    "2.0.0",
+			want: -1,
+		},
+		{
+			name: "minor over patch",
+			a:    "1.3.0",
+			b:    "1.2.9",
+			want: 1,
+		},
+		{
+			name: "pre-release is lower",
+			a:    "1.0.0-rc.1",
+			b:    "1.0.0",
+			want: -1,
+		},
+		{
+			name: "build metadata is ignored",
+			a:    "2.0.0+incompatible",
+			b:    "2.0.0",
+			want: 0,
+		},
+		{
+			name: "numeric identifiers with leading zeros",
+			a:    "1.0.0-rc.01",
+			b:    "1.0.0-rc.1",
+			want: 0,
+		},
+		{
+			name: "build metadata is ignored with pre-release",
+			a:    "1.0.0-rc.1+build.2",
+			b:    "1.0.0-rc.1+build.1",
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ParseSeparated(tt.a, "", "-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ParseSeparated(tt.b, "", "-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Compare(a, b); got != tt.want {
+				t.Errorf("Compare() = %v, want %v", got, tt.want)
+			}
+			if got := Compare(b, a); got != -tt.want {
+				t.Errorf("Compare() reversed = %v, want %v", got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestSemVer_String(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "plain",
+			s:    "v1.2.3",
+			want: "v1.2.3",
+		},
+		{
+			name: "pre-release",
+			s:    "v1.2.3-rc.1",
+			want: "v1.2.3-rc.1",
+		},
+		{
+			name: "build metadata only",
+			s:    "v2.0.0+incompatible",
+			want: "v2.0.0+incompatible",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sv, err := ParseSeparated(tt.s, "", "-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sv.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	sv := New(1, 2, 3)
+	if sv.Major() != 1 || sv.Minor() != 2 || sv.Patch() != 3 {
+		t.Errorf("New(1, 2, 3) = %d.%d.%d", sv.Major(), sv.Minor(), sv.Patch())
+	}
+	if sv.String() != "1.2.3" || sv.SuffixSeparator() != "-" {
+		t.Errorf("New(1, 2, 3) = %q with separator %q, want 1.2.3 with '-'", sv, sv.SuffixSeparator())
+	}
+	if again := MustParse(sv.String()); Compare(again, sv) != 0 {
+		t.Errorf("MustParse(%q) = %v", sv, again)
+	}
+}
+
+func TestMustParse_prerelease(t *testing.T) {
+	sv := MustParse("1.2.3-rc.1+b.5")
+	if got, want := sv.Prerelease(), New(1, 2, 3).WithPrerelease("rc.1").Prerelease(); got != want {
+		t.Errorf("Prerelease() = %q, want %q", got, want)
+	}
+	if _, err := ParsePrerelease(sv.Prerelease()); err != nil {
+		t.Errorf("ParsePrerelease(%q) error = %v", sv.Prerelease(), err)
+	}
+	if got := sv.WithBuild("b.6").String(); got != "1.2.3-rc.1+b.6" {
+		t.Errorf("WithBuild() = %q, want 1.2.3-rc.1+b.6", got)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParse() of an invalid version didn't panic")
+		}
+	}()
+	MustParse("1.2")
+}
+
+func TestSemVer_With(t *testing.T) {
+	tests := []struct {
+		name string
+		sv   SemVer
+		with func(SemVer) SemVer
+		want string
+	}{
+		{
+			name: "prefix",
+			sv:   New(1, 2, 3),
+			with: func(sv SemVer) SemVer { return sv.WithPrefix("v") },
+			want: "v1.2.3",
+		},
+		{
+			name: "pre-release",
+			sv:   New(1, 2, 3).WithBuild("b.1"),
+			with: func(sv SemVer) SemVer { return sv.WithPrerelease("rc.1") },
+			want: "1.2.3-rc.1+b.1",
+		},
+		{
+			name: "pre-release without separator",
+			sv:   MustParse("v1.2.3-beta"),
+			with: func(sv SemVer) SemVer { return sv.WithPrerelease("rc.1") },
+			want: "v1.2.3-rc.1",
+		},
+		{
+			name: "remove pre-release",
+			sv:   MustParse("1.2.3-rc.1+b.1"),
+			with: func(sv SemVer) SemVer { return sv.WithPrerelease("") },
+			want: "1.2.3+b.1",
+		},
+		{
+			name: "build",
+			sv:   MustParse("1.2.3-rc.1+b.1"),
+			with: func(sv SemVer) SemVer { return sv.WithBuild("b.2") },
+			want: "1.2.3-rc.1+b.2",
+		},
+		{
+			name: "remove build",
+			sv:   New(1, 2, 3).WithPrerelease("rc.1").WithBuild("b.1"),
+			with: func(sv SemVer) SemVer { return sv.WithBuild("") },
+			want: "1.2.3-rc.1",
+		},
+		{
+			name: "bump major",
+			sv:   MustParse("v1.2.3-rc.1"),
+			with: SemVer.BumpMajor,
+			want: "v2.0.0-rc.1",
+		},
+		{
+			name: "bump minor",
+			sv:   MustParse("1.2.3"),
+			with: SemVer.BumpMinor,
+			want: "1.3.0",
+		},
+		{
+			name: "bump patch",
+			sv:   MustParse("1.2.3"),
+			with: SemVer.BumpPatch,
+			want: "1.2.4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := tt.sv.String()
+			if got := tt.with(tt.sv).String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if tt.sv.String() != before {
+				t.Errorf("original modified to %q, want %q", tt.sv, before)
+			}
+		})
+	}
+}
+
+func TestSemVer_getters(t *testing.T) {
+	sv, err := ParseSeparated("app@1.2.3~rc.1+b", "@", "~")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{sv.PrefixString(), sv.PrefixSeparator(), sv.SuffixString(), sv.SuffixSeparator(), sv.Prerelease(), sv.Build()}
+	want := []string{"app", "@", "rc.1+b", "~", "rc.1", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getters = %q, want %q", got, want)
+	}
+}
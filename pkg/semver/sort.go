@@ -0,0 +1,144 @@
+package semver
+
+import (
+	"iter"
+	"slices"
+)
+
+// CompareDesc is Compare in reverse, for sorting in descending order with slices.SortFunc
+func CompareDesc(a, b SemVer) int {
+	return Compare(b, a)
+}
+
+// Equal reports whether a and b have equal precedence. Build metadata is ignored
+func Equal(a, b SemVer) bool {
+	return Compare(a, b) == 0
+}
+
+// Min returns the lowest of a and b. If they have equal precedence, a is returned
+func Min(a, b SemVer) SemVer {
+	if Compare(a, b) <= 0 {
+		return a
+	}
+	return b
+}
+
+// Sort sorts vs in ascending order of precedence. Versions of equal precedence keep their order
+func Sort(vs []SemVer) {
+	slices.SortStableFunc(vs, Compare)
+}
+
+// SortDesc sorts vs in descending order of precedence. Versions of equal precedence keep their order
+func SortDesc(vs []SemVer) {
+	slices.SortStableFunc(vs, CompareDesc)
+}
+
+// Sorted returns a sorted copy of vs, in ascending order of precedence
+func Sorted(vs []SemVer) []SemVer {
+	rv := slices.Clone(vs)
+	Sort(rv)
+	return rv
+}
+
+// MinSlice returns the lowest version in a list, or the zero version if it is empty. vs is not modified
+func MinSlice(vs []SemVer) SemVer {
+	return MinSeq(slices.Values(vs))
+}
+
+// MaxSeq returns the highest version in seq, or the zero version if it is empty. Of versions with equal precedence, the first is returned
+func MaxSeq(seq iter.Seq[SemVer]) SemVer {
+	return fold(seq, Max)
+}
+
+// MinSeq returns the lowest version in seq, or the zero version if it is empty. Of versions with equal precedence, the first is returned
+func MinSeq(seq iter.Seq[SemVer]) SemVer {
+	return fold(seq, Min)
+}
+
+// fold reduces seq with f
+func fold(seq iter.Seq[SemVer], f func(a, b SemVer) SemVer) SemVer {
+	var rv SemVer
+	first := true
+	for v := range seq {
+		if first {
+			rv, first = v, false
+			continue
+		}
+		rv = f(rv, v)
+	}
+	return rv
+}
+
+// Collection is a set of versions, kept in ascending order of precedence. Versions of equal precedence are only stored once, the first one added is kept
+type Collection struct {
+	vs []SemVer
+}
+
+// NewCollection returns a collection of vs
+func NewCollection(vs ...SemVer) *Collection {
+	c := &Collection{}
+	c.Add(vs...)
+	return c
+}
+
+// Add adds vs to c, skipping versions with the same precedence as one already in c. It reports whether any version was added
+func (c *Collection) Add(vs ...SemVer) bool {
+	added := false
+	for _, v := range vs {
+		i, found := slices.BinarySearchFunc(c.vs, v, Compare)
+		if found {
+			continue
+		}
+		c.vs = slices.Insert(c.vs, i, v)
+		added = true
+	}
+	return added
+}
+
+// Contains reports whether c has a version with the same precedence as v
+func (c *Collection) Contains(v SemVer) bool {
+	_, found := slices.BinarySearchFunc(c.vs, v, Compare)
+	return found
+}
+
+// Len returns the number of versions in c
+func (c *Collection) Len() int {
+	return len(c.vs)
+}
+
+// Max returns the highest version in c, and false if c is empty
+func (c *Collection) Max() (SemVer, bool) {
+	if len(c.vs) == 0 {
+		return SemVer{}, false
+	}
+	return c.vs[len(c.vs)-1], true
+}
+
+// Min returns the lowest version in c, and false if c is empty
+func (c *Collection) Min() (SemVer, bool) {
+	if len(c.vs) == 0 {
+		return SemVer{}, false
+	}
+	return c.vs[0], true
+}
+
+// All returns an iterator over the versions in c, in ascending order
+func (c *Collection) All() iter.Seq[SemVer] {
+	return slices.Values(c.vs)
+}
+
+// Backward returns an iterator over the versions in c, in descending order
+func (c *Collection) Backward() iter.Seq[SemVer] {
+	return func(yield func(SemVer) bool) {
+		for i := len(c.vs) - 1; i >= 0; i-- {
+			if !yield(c.vs[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns a copy of the versions in c, in ascending order
+func (c *Collection) Slice() []SemVer {
+	return slices.Clone(c.vs)
+}
@@ -0,0 +1,129 @@
+package semver
+
+import (
+	"slices"
+	"testing"
+)
+
+func mustParseAll(t *testing.T, ss ...string) []SemVer {
+	t.Helper()
+	vs := make([]SemVer, len(ss))
+	for i, s := range ss {
+		v, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", s, err)
+		}
+		vs[i] = v
+	}
+	return vs
+}
+
+func strs(vs []SemVer) []string {
+	rv := make([]string, len(vs))
+	for i, v := range vs {
+		rv[i] = v.String()
+	}
+	return rv
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []string
+		want     []string
+		wantDesc []string
+	}{
+		{
+			name:     "empty",
+			in:       []string{},
+			want:     []string{},
+			wantDesc: []string{},
+		},
+		{
+			name:     "precedence",
+			in:       []string{"1.10.0", "2.0.0", "1.2.3", "1.10.0-rc.1", "0.9.9"},
+			want:     []string{"0.9.9", "1.2.3", "1.10.0-rc.1", "1.10.0", "2.0.0"},
+			wantDesc: []string{"2.0.0", "1.10.0", "1.10.0-rc.1", "1.2.3", "0.9.9"},
+		},
+		{
+			name:     "stable for equal precedence",
+			in:       []string{"v1.0.0", "1.0.0+b", "0.1.0", "1.0.0"},
+			want:     []string{"0.1.0", "v1.0.0", "1.0.0+b", "1.0.0"},
+			wantDesc: []string{"v1.0.0", "1.0.0+b", "1.0.0", "0.1.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := mustParseAll(t, tt.in...)
+			got := Sorted(in)
+			if !slices.Equal(strs(got), tt.want) {
+				t.Errorf("Sorted() = %v, want %v", strs(got), tt.want)
+			}
+			if !slices.Equal(strs(in), tt.in) {
+				t.Errorf("Sorted() modified its input to %v", strs(in))
+			}
+			Sort(in)
+			if !slices.Equal(strs(in), tt.want) {
+				t.Errorf("Sort() = %v, want %v", strs(in), tt.want)
+			}
+			in = mustParseAll(t, tt.in...)
+			SortDesc(in)
+			if !slices.Equal(strs(in), tt.wantDesc) {
+				t.Errorf("SortDesc() = %v, want %v", strs(in), tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestMinMaxSlice(t *testing.T) {
+	in := mustParseAll(t, "1.2.3", "v2.0.0", "1.0.0-rc.1", "2.0.0", "1.0.0-alpha")
+	if got := MaxSlice(in); got.String() != "v2.0.0" {
+		t.Errorf("MaxSlice() = %v, want v2.0.0", got)
+	}
+	if got := MinSlice(in); got.String() != "1.0.0-alpha" {
+		t.Errorf("MinSlice() = %v, want 1.0.0-alpha", got)
+	}
+	if got := MaxSeq(slices.Values(in)); got.String() != "v2.0.0" {
+		t.Errorf("MaxSeq() = %v, want v2.0.0", got)
+	}
+	if got := MinSeq(slices.Values(in)); got.String() != "1.0.0-alpha" {
+		t.Errorf("MinSeq() = %v, want 1.0.0-alpha", got)
+	}
+	if got := strs(in); !slices.Equal(got, []string{"1.2.3", "v2.0.0", "1.0.0-rc.1", "2.0.0", "1.0.0-alpha"}) {
+		t.Errorf("MaxSlice() modified its input to %v", got)
+	}
+	if got := MaxSlice(nil); got != (SemVer{}) {
+		t.Errorf("MaxSlice(nil) = %v, want zero version", got)
+	}
+}
+
+func TestCollection(t *testing.T) {
+	c := NewCollection(mustParseAll(t, "1.2.3", "v1.0.0", "1.0.0+build", "2.0.0-rc.1")...)
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", c.Len())
+	}
+	if c.Add(mustParseAll(t, "v1.2.3")...) {
+		t.Error("Add() of a duplicate reported true")
+	}
+	if !c.Add(mustParseAll(t, "0.1.0")...) {
+		t.Error("Add() of a new version reported false")
+	}
+	if got, want := strs(c.Slice()), []string{"0.1.0", "v1.0.0", "1.2.3", "2.0.0-rc.1"}; !slices.Equal(got, want) {
+		t.Errorf("Slice() = %v, want %v", got, want)
+	}
+	if got, want := strs(slices.Collect(c.Backward())), []string{"2.0.0-rc.1", "1.2.3", "v1.0.0", "0.1.0"}; !slices.Equal(got, want) {
+		t.Errorf("Backward() = %v, want %v", got, want)
+	}
+	if !c.Contains(mustParseAll(t, "1.0.0+other")[0]) {
+		t.Error("Contains() = false, want true")
+	}
+	if max, _ := c.Max(); max.String() != "2.0.0-rc.1" {
+		t.Errorf("Max() = %v, want 2.0.0-rc.1", max)
+	}
+	if min, _ := c.Min(); min.String() != "0.1.0" {
+		t.Errorf("Min() = %v, want 0.1.0", min)
+	}
+	if _, ok := NewCollection().Max(); ok {
+		t.Error("Max() of empty collection reported true")
+	}
+}
@@ -0,0 +1,118 @@
+package tmpl
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+// Data is the data of a version template
+type Data struct {
+	// SemVer is the version, for formatting with printf, like {{printf "%.2v" .SemVer}}
+	SemVer semver.SemVer
+	Prefix string
+	Major  uint
+	Minor  uint
+	Patch  uint
+	// Pre is the pre-release and Build the build metadata, without separators
+	Pre   string
+	Build string
+	// Version is major.minor.patch, and Full the complete version string
+	Version string
+	Full    string
+	// Date is the build date
+	Date time.Time
+}
+
+// funcs are the helper functions of version templates
+var funcs = template.FuncMap{
+	"pad":      pad,
+	"lower":    strings.ToLower,
+	"upper":    strings.ToUpper,
+	"replace":  strings.ReplaceAll,
+	"sanitize": sanitize,
+	"date":     date,
+}
+
+// IsTemplate reports whether s is a template rather than a format name
+func IsTemplate(s string) bool {
+	return strings.Contains(s, "{{")
+}
+
+// Template is a version template, like '{{.Prefix}}{{.Major}}.{{.Minor}}{{if .Pre}}-{{.Pre}}{{end}}'. Besides the text/template builtins, it has the functions
+//
+//	pad N V       V, left padded with zeros to N characters, like {{pad 3 .Minor}}
+//	lower S       S in lower case
+//	upper S       S in upper case
+//	replace S O N S with all O replaced by N
+//	sanitize S    S with runs of characters other than alphanumerics, '.', '_' and '-' replaced by '-'
+//	date L T      T in the time.Format layout L, in UTC, like {{date "20060102" .Date}}
+type Template struct {
+	t *template.Template
+}
+
+// New parses a version template
+func New(text string) (*Template, error) {
+	t, err := template.New("format").Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{t: t}, nil
+}
+
+// Execute renders sv with the build date
+func (t *Template) Execute(sv semver.SemVer, date time.Time) (string, error) {
+	prefix, _ := sv.PreSuffix()
+	d := Data{
+		SemVer:  sv,
+		Prefix:  prefix,
+		Major:   sv.Major(),
+		Minor:   sv.Minor(),
+		Patch:   sv.Patch(),
+		Pre:     sv.Prerelease(),
+		Build:   sv.Build(),
+		Version: sv.Version(),
+		Full:    sv.String(),
+		Date:    date,
+	}
+	b := strings.Builder{}
+	if err := t.t.Execute(&b, d); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// pad returns v left padded with zeros to n characters
+func pad(n int, v any) string {
+	s := fmt.Sprint(v)
+	if len(s) >= n {
+		return s
+	}
+	return strings.Repeat("0", n-len(s)) + s
+}
+
+// sanitize replaces runs of characters other than alphanumerics, '.', '_' and '-' with '-'
+func sanitize(s string) string {
+	b := strings.Builder{}
+	replaced := false
+	for _, r := range s {
+		if r == '.' || r == '_' || r == '-' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+			replaced = false
+			continue
+		}
+		if !replaced {
+			b.WriteByte('-')
+			replaced = true
+		}
+	}
+	return b.String()
+}
+
+// date returns t in layout, in UTC
+func date(layout string, t time.Time) string {
+	return t.UTC().Format(layout)
+}
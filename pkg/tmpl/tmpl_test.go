@@ -0,0 +1,63 @@
+package tmpl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adamhassel/semvergo/pkg/semver"
+)
+
+func TestTemplate_Execute(t *testing.T) {
+	sv, err := semver.ParseSeparated("v1.2.3-rc.1+b.5", "", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	release, err := semver.ParseSeparated("1.2.3", "", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := time.Date(2026, 10, 17, 23, 30, 0, 0, time.FixedZone("", -2*60*60))
+	tests := []struct {
+		name    string
+		text    string
+		sv      semver.SemVer
+		want    string
+		wantErr bool
+	}{
+		{name: "short", text: "{{.Prefix}}{{.Major}}.{{.Minor}}{{if .Pre}}-{{.Pre}}{{end}}", sv: sv, want: "v1.2-rc.1"},
+		{name: "short release", text: "{{.Prefix}}{{.Major}}.{{.Minor}}{{if .Pre}}-{{.Pre}}{{end}}", sv: release, want: "1.2"},
+		{name: "underscores", text: `{{replace .Version "." "_"}}`, sv: sv, want: "1_2_3"},
+		{name: "release name", text: `release-{{.Version}}{{with .Pre}}-{{replace . "." ""}}{{end}}`, sv: sv, want: "release-1.2.3-rc1"},
+		{name: "printf", text: `{{printf "%.2v" .SemVer}}`, sv: sv, want: "v1.2"},
+		{name: "full", text: "{{.Full}} {{.Build}}", sv: sv, want: "v1.2.3-rc.1+b.5 b.5"},
+		{name: "pad", text: "{{.Major}}{{pad 3 .Minor}}{{pad 3 .Patch}}", sv: sv, want: "1002003"},
+		{name: "lower and upper", text: "{{upper .Pre}} {{lower .Prefix}}", sv: sv, want: "RC.1 v"},
+		{name: "sanitize", text: `{{sanitize "feature/login:+x"}}`, sv: sv, want: "feature-login-x"},
+		{name: "date", text: `{{.Version}}-{{date "20060102" .Date}}`, sv: sv, want: "1.2.3-20261018"},
+		{name: "unknown field", text: "{{.Nope}}", sv: sv, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := New(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tmpl.Execute(tt.sv, date)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("{{.Major"); err == nil {
+		t.Error("New() of an invalid template didn't fail")
+	}
+	if !IsTemplate("{{.Major}}") || IsTemplate("pep440") {
+		t.Error("IsTemplate() is wrong")
+	}
+}
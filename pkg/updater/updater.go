@@ -0,0 +1,356 @@
+package updater
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// VersionGroup is the name of the capture group holding the version string in Regexp updaters
+const VersionGroup = "version"
+
+var ErrNoVersion = errors.New("no version found")
+var ErrUnsupportedFile = errors.New("unsupported file type")
+var ErrNoVersionGroup = errors.New("regular expression has no '" + VersionGroup + "' capture group")
+var ErrInvalidVersion = errors.New("invalid version string")
+
+// Updater replaces the version string in the contents of a file, leaving everything else as is
+type Updater interface {
+	Update(content []byte, version string) ([]byte, error)
+}
+
+// UpdaterFunc is a function implementing Updater
+type UpdaterFunc func(content []byte, version string) ([]byte, error)
+
+func (f UpdaterFunc) Update(content []byte, version string) ([]byte, error) {
+	return f(content, version)
+}
+
+var (
+	chartVersionRE = regexp.MustCompile(`(?m)^version:[ \t]*["']?(?P<version>[^"'\s#]+)`)
+	chartAppRE     = regexp.MustCompile(`(?m)^appVersion:[ \t]*["']?(?P<version>[^"'\s#]+)`)
+	tomlVersionRE  = regexp.MustCompile(`(?m)^[ \t]*version[ \t]*=[ \t]*["'](?P<version>[^"'\n]*)["']`)
+	tomlHeaderRE   = regexp.MustCompile(`(?m)^[ \t]*\[([^\[\]\n]+)\][ \t]*(?:#.*)?$`)
+	tomlTableRE    = regexp.MustCompile(`(?m)^[ \t]*\[`)
+	plainVersionRE = regexp.MustCompile(`^\s*(?P<version>\S+)`)
+)
+
+// ForFile returns the Updater for a known manifest file, based on its base name
+func ForFile(name string) (Updater, error) {
+	switch filepath.Base(name) {
+	case "package.json":
+		return UpdaterFunc(packageJSON), nil
+	case "Chart.yaml", "Chart.yml":
+		return UpdaterFunc(chart), nil
+	case "Cargo.toml":
+		return tomlSections("package", "workspace.package"), nil
+	case "pyproject.toml":
+		return tomlSections("project", "tool.poetry"), nil
+	case "pom.xml":
+		return UpdaterFunc(pom), nil
+	case "VERSION":
+		return UpdaterFunc(func(c []byte, v string) ([]byte, error) {
+			if len(bytes.TrimSpace(c)) == 0 {
+				return []byte(v + "\n"), nil
+			}
+			return replaceGroup(c, plainVersionRE, v, false)
+		}), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedFile, name)
+}
+
+// Regexp returns an Updater replacing every match of the capture group named "version" in expr
+func Regexp(expr string) (Updater, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	if re.SubexpIndex(VersionGroup) < 0 {
+		return nil, ErrNoVersionGroup
+	}
+	return UpdaterFunc(func(c []byte, v string) ([]byte, error) {
+		return replaceGroup(c, re, v, true)
+	}), nil
+}
+
+// replaceGroup replaces the "version" group of the first (or all, if all is true) match of re in content with version
+func replaceGroup(content []byte, re *regexp.Regexp, version string, all bool) ([]byte, error) {
+	n := 1
+	if all {
+		n = -1
+	}
+	matches := re.FindAllSubmatchIndex(content, n)
+	if len(matches) == 0 {
+		return nil, ErrNoVersion
+	}
+	g := re.SubexpIndex(VersionGroup)
+	var b bytes.Buffer
+	last := 0
+	for _, m := range matches {
+		start, end := m[2*g], m[2*g+1]
+		if start < 0 {
+			continue
+		}
+		b.Write(content[last:start])
+		b.WriteString(version)
+		last = end
+	}
+	b.Write(content[last:])
+	return b.Bytes(), nil
+}
+
+// chart updates both version and, if present, appVersion in a Helm Chart.yaml
+func chart(c []byte, v string) ([]byte, error) {
+	c, err := replaceGroup(c, chartVersionRE, v, false)
+	if err != nil {
+		return nil, err
+	}
+	if !chartAppRE.Match(c) {
+		return c, nil
+	}
+	return replaceGroup(c, chartAppRE, v, false)
+}
+
+// tomlSections returns an Updater replacing the version key in the first of the named TOML tables that has one
+func tomlSections(names ...string) Updater {
+	return UpdaterFunc(func(c []byte, v string) ([]byte, error) {
+		headers := tomlHeaderRE.FindAllSubmatchIndex(c, -1)
+		for _, name := range names {
+			for _, h := range headers {
+				if strings.TrimSpace(string(c[h[2]:h[3]])) != name {
+					continue
+				}
+				start, end := h[1], len(c)
+				if next := tomlTableRE.FindIndex(c[start:]); next != nil {
+					end = start + next[0]
+				}
+				body, err := replaceGroup(c[start:end], tomlVersionRE, v, false)
+				if errors.Is(err, ErrNoVersion) {
+					continue
+				}
+				rv := append([]byte{}, c[:start]...)
+				rv = append(rv, body...)
+				return append(rv, c[end:]...), nil
+			}
+		}
+		return nil, ErrNoVersion
+	})
+}
+
+// packageJSON updates the top-level version of a package.json, ignoring version keys in nested objects
+func packageJSON(c []byte, v string) ([]byte, error) {
+	// container is an enclosing object or array. key is true if the next token in an object is a key
+	type container struct {
+		object, key bool
+	}
+	var stack []container
+	// valueDone expects a key next, if the value just read is in an object
+	valueDone := func() {
+		if n := len(stack); n > 0 && stack[n-1].object {
+			stack[n-1].key = true
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(c))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, ErrNoVersion
+		}
+		if err != nil {
+			return nil, err
+		}
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:len(stack)-1]
+			valueDone()
+			continue
+		}
+		if n := len(stack); n > 0 && stack[n-1].key {
+			stack[n-1].key = false
+			if n != 1 || tok != "version" {
+				continue
+			}
+			start := dec.InputOffset()
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := tok.(string); !ok {
+				return nil, ErrNoVersion
+			}
+			end := dec.InputOffset()
+			// the value is the quoted string after the ':'
+			start += int64(bytes.IndexByte(c[start:end], '"'))
+			rv := append([]byte{}, c[:start]...)
+			rv, err = appendJSONString(rv, v)
+			if err != nil {
+				return nil, err
+			}
+			return append(rv, c[end:]...), nil
+		}
+		if d, ok := tok.(json.Delim); ok {
+			stack = append(stack, container{object: d == '{', key: d == '{'})
+			continue
+		}
+		valueDone()
+	}
+}
+
+// appendJSONString appends s to b as a quoted JSON string
+func appendJSONString(b []byte, s string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return nil, err
+	}
+	return append(b, bytes.TrimSuffix(buf.Bytes(), []byte("\n"))...), nil
+}
+
+// pom updates the project version in a Maven pom.xml, ignoring the parent and dependency versions
+func pom(c []byte, v string) ([]byte, error) {
+	var escaped bytes.Buffer
+	if err := xml.EscapeText(&escaped, []byte(v)); err != nil {
+		return nil, err
+	}
+	v = escaped.String()
+	dec := xml.NewDecoder(bytes.NewReader(c))
+	var path []string
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, ErrNoVersion
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			path = append(path, t.Name.Local)
+			if len(path) != 2 || path[0] != "project" || path[1] != "version" {
+				continue
+			}
+			start := dec.InputOffset()
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			end := dec.InputOffset()
+			if _, ok := tok.(xml.CharData); !ok {
+				// an empty element, insert the version after its start tag
+				end = start
+				if bytes.HasSuffix(c[:start], []byte("/>")) {
+					// <version/> becomes <version>v</version>
+					start -= 2
+					end = start + 2
+					v = ">" + v + "</" + t.Name.Local + ">"
+				}
+			}
+			text := c[start:end]
+			start += int64(len(text) - len(bytes.TrimLeft(text, " \t\r\n")))
+			end -= int64(len(text) - len(bytes.TrimRight(text, " \t\r\n")))
+			rv := append([]byte{}, c[:start]...)
+			rv = append(rv, v...)
+			return append(rv, c[end:]...), nil
+		case xml.EndElement:
+			path = path[:len(path)-1]
+		}
+	}
+}
+
+// Target is a file to update, and the Updater to use for it. If Dir is set, diffs show Path relative to it
+type Target struct {
+	Path    string
+	Dir     string
+	Updater Updater
+}
+
+// ParseTarget parses a target specification. This is either a path to a known manifest file, or a path and a regular expression separated by ':', in which case the "version" capture group of the expression is replaced
+func ParseTarget(s string) (Target, error) {
+	path, expr, found := strings.Cut(s, ":")
+	var u Updater
+	var err error
+	if found {
+		u, err = Regexp(expr)
+	} else {
+		u, err = ForFile(path)
+	}
+	if err != nil {
+		return Target{}, fmt.Errorf("%s: %w", s, err)
+	}
+	return Target{Path: path, Updater: u}, nil
+}
+
+// Apply writes version to the target file. If dryRun is true, the file is left untouched and a diff of the changes is written to w instead
+func (t Target) Apply(version string, dryRun bool, w io.Writer) error {
+	if version == "" || strings.ContainsAny(version, "\r\n") {
+		return fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+	old, err := os.ReadFile(t.Path)
+	if err != nil {
+		return err
+	}
+	updated, err := t.Updater.Update(old, version)
+	if err != nil {
+		return fmt.Errorf("%s: %w", t.Path, err)
+	}
+	if dryRun {
+		return Diff(w, t.name(), old, updated)
+	}
+	if bytes.Equal(old, updated) {
+		return nil
+	}
+	fi, err := os.Stat(t.Path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.Path, updated, fi.Mode().Perm())
+}
+
+// name returns the path of the target, relative to t.Dir if possible
+func (t Target) name() string {
+	if t.Dir == "" {
+		return t.Path
+	}
+	dir, err := filepath.Abs(t.Dir)
+	if err != nil {
+		return t.Path
+	}
+	path, err := filepath.Abs(t.Path)
+	if err != nil {
+		return t.Path
+	}
+	name, err := filepath.Rel(dir, path)
+	if err != nil {
+		return t.Path
+	}
+	return filepath.ToSlash(name)
+}
+
+// Diff writes a unified-style diff of the lines changed between a and b to w. Updaters never add or remove lines, so lines are compared pairwise.
+func Diff(w io.Writer, path string, a, b []byte) error {
+	al := strings.Split(string(a), "\n")
+	bl := strings.Split(string(b), "\n")
+	header := false
+	for i := 0; i < len(al) && i < len(bl); i++ {
+		if al[i] == bl[i] {
+			continue
+		}
+		if !header {
+			if _, err := fmt.Fprintf(w, "--- a/%s\n+++ b/%s\n", path, path); err != nil {
+				return err
+			}
+			header = true
+		}
+		if _, err := fmt.Fprintf(w, "@@ -%d +%d @@\n-%s\n+%s\n", i+1, i+1, al[i], bl[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package updater
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestForFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "package.json",
+			file: "package.json",
+			content: `{
+  "name": "foo",
+  "version": "1.0.0",
+  "dependencies": {
+    "bar": "^2.0.0"
+  }
+}
+`,
+			want: `{
+  "name": "foo",
+  "version": "1.2.3",
+  "dependencies": {
+    "bar": "^2.0.0"
+  }
+}
+`,
+		},
+		{
+			name:    "package.json, nested version first",
+			file:    "package.json",
+			content: `{"config":{"version":"abc"},"files":[{"version":"x"}],"version" : "1.0.0"}`,
+			want:    `{"config":{"version":"abc"},"files":[{"version":"x"}],"version" : "1.2.3"}`,
+		},
+		{
+			name:    "package.json, nested version only",
+			file:    "package.json",
+			content: `{"config":{"version":"abc"}}`,
+			wantErr: ErrNoVersion,
+		},
+		{
+			name:    "package.json, version value as key",
+			file:    "package.json",
+			content: `{"name":"version","version":"1.0.0"}`,
+			want:    `{"name":"version","version":"1.2.3"}`,
+		},
+		{
+			name: "Cargo.toml, ignores dependency versions",
+			file: "Cargo.toml",
+			content: `[package]
+name = "foo"
+version = "0.1.0" # the version
+
+[dependencies]
+bar = { version = "2.0" }
+`,
+			want: `[package]
+name = "foo"
+version = "1.2.3" # the version
+
+[dependencies]
+bar = { version = "2.0" }
+`,
+		},
+		{
+			name: "Cargo.toml, version in later section only",
+			file: "Cargo.toml",
+			content: `[dependencies]
+version = "9.9.9"
+
+[package]
+name = "foo"
+version = "0.1.0"
+`,
+			want: `[dependencies]
+version = "9.9.9"
+
+[package]
+name = "foo"
+version = "1.2.3"
+`,
+		},
+		{
+			name: "Cargo.toml, workspace",
+			file: "Cargo.toml",
+			content: `[workspace]
+members = ["a"]
+
+[workspace.package]
+version = '0.1.0'
+`,
+			want: `[workspace]
+members = ["a"]
+
+[workspace.package]
+version = '1.2.3'
+`,
+		},
+		{
+			name: "Cargo.toml, no version",
+			file: "Cargo.toml",
+			content: `[package]
+name = "foo"
+`,
+			wantErr: ErrNoVersion,
+		},
+		{
+			name: "pyproject.toml, poetry",
+			file: "pyproject.toml",
+			content: `[build-system]
+requires = ["poetry-core"]
+
+[tool.poetry]
+name = "foo"
+version = "0.1.0"
+`,
+			want: `[build-system]
+requires = ["poetry-core"]
+
+[tool.poetry]
+name = "foo"
+version = "1.2.3"
+`,
+		},
+		{
+			name: "Chart.yaml",
+			file: "Chart.yaml",
+			content: `apiVersion: v2
+name: foo
+version: 0.1.0
+appVersion: "0.1.0"
+dependencies:
+  - name: bar
+    version: 1.0.0
+`,
+			want: `apiVersion: v2
+name: foo
+version: 1.2.3
+appVersion: "1.2.3"
+dependencies:
+  - name: bar
+    version: 1.0.0
+`,
+		},
+		{
+			name: "pom.xml",
+			file: "pom.xml",
+			content: `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <parent>
+    <version>5.0.0</version>
+  </parent>
+  <artifactId>foo</artifactId>
+  <version> 0.1.0-SNAPSHOT </version>
+  <dependencies>
+    <dependency>
+      <version>2.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`,
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <parent>
+    <version>5.0.0</version>
+  </parent>
+  <artifactId>foo</artifactId>
+  <version> 1.2.3 </version>
+  <dependencies>
+    <dependency>
+      <version>2.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`,
+		},
+		{
+			name:    "pom.xml, empty version",
+			file:    "pom.xml",
+			content: `<project><version></version><name>x</name></project>`,
+			want:    `<project><version>1.2.3</version><name>x</name></project>`,
+		},
+		{
+			name:    "pom.xml, self-closing version",
+			file:    "pom.xml",
+			content: `<project><version/><name>x</name></project>`,
+			want:    `<project><version>1.2.3</version><name>x</name></project>`,
+		},
+		{
+			name:    "VERSION",
+			file:    "VERSION",
+			content: "0.1.0\n",
+			want:    "1.2.3\n",
+		},
+		{
+			name:    "VERSION, empty",
+			file:    "VERSION",
+			content: "",
+			want:    "1.2.3\n",
+		},
+		{
+			name:    "unsupported",
+			file:    "setup.cfg",
+			wantErr: ErrUnsupportedFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := ForFile(tt.file)
+			if err == nil {
+				var got []byte
+				got, err = u.Update([]byte(tt.content), "1.2.3")
+				if err == nil && string(got) != tt.want {
+					t.Errorf("Update() got = %s, want %s", got, tt.want)
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestForFile_escape(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		version string
+		want    string
+	}{
+		{
+			name:    "package.json",
+			file:    "package.json",
+			content: `{"version": "1.0.0"}`,
+			version: `1.2.3-a"b\c<d>`,
+			want:    `{"version": "1.2.3-a\"b\\c<d>"}`,
+		},
+		{
+			name:    "pom.xml",
+			file:    "pom.xml",
+			content: `<project><version>1.0.0</version></project>`,
+			version: `1.2.3-a<b>&c`,
+			want:    `<project><version>1.2.3-a&lt;b&gt;&amp;c</version></project>`,
+		},
+		{
+			name:    "pom.xml, self-closing version",
+			file:    "pom.xml",
+			content: `<project><version/></project>`,
+			version: `1.2.3&c`,
+			want:    `<project><version>1.2.3&amp;c</version></project>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := ForFile(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := u.Update([]byte(tt.content), tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Update() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegexp(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		content string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "all matches",
+			expr:    `semvergo@v(?P<version>[0-9.]+)`,
+			content: "go install semvergo@v0.1.0\nuse semvergo@v0.1.0\n",
+			want:    "go install semvergo@v1.2.3\nuse semvergo@v1.2.3\n",
+		},
+		{
+			name:    "no match",
+			expr:    `foo (?P<version>\d+)`,
+			content: "bar 1",
+			wantErr: ErrNoVersion,
+		},
+		{
+			name:    "no group",
+			expr:    `foo (\d+)`,
+			wantErr: ErrNoVersionGroup,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := Regexp(tt.expr)
+			if err == nil {
+				var got []byte
+				got, err = u.Update([]byte(tt.content), "1.2.3")
+				if err == nil && string(got) != tt.want {
+					t.Errorf("Update() got = %s, want %s", got, tt.want)
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTarget_Apply(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "VERSION")
+	if err := os.WriteFile(path, []byte("0.1.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	target, err := ParseTarget(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target.Dir = dir
+
+	var diff bytes.Buffer
+	if err := target.Apply("1.2.3", true, &diff); err != nil {
+		t.Fatal(err)
+	}
+	wantDiff := "--- a/VERSION\n+++ b/VERSION\n@@ -1 +1 @@\n-0.1.0\n+1.2.3\n"
+	if diff.String() != wantDiff {
+		t.Errorf("Apply() diff = %q, want %q", diff.String(), wantDiff)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "0.1.0\n" {
+		t.Errorf("Apply() dry run modified file: %q", got)
+	}
+
+	if err := target.Apply("1.2.3", false, &diff); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "1.2.3\n" {
+		t.Errorf("Apply() got = %q, want %q", got, "1.2.3\n")
+	}
+}